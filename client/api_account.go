@@ -59,7 +59,7 @@ func (c *client) CreatePaymentAccount(ctx context.Context, address string, txOpt
 		return "", err
 	}
 	msgCreatePaymentAccount := paymentTypes.NewMsgCreatePaymentAccount(accAddress.String())
-	tx, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgCreatePaymentAccount}, &txOption)
+	tx, err := c.broadcastTx(ctx, []sdk.Msg{msgCreatePaymentAccount}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -175,8 +175,8 @@ func (c *client) Transfer(ctx context.Context, toAddress string, amount math.Int
 	if err != nil {
 		return "", err
 	}
-	msgSend := bankTypes.NewMsgSend(c.MustGetDefaultAccount().GetAddress(), toAddr, sdk.Coins{sdk.Coin{Denom: gnfdSdkTypes.Denom, Amount: amount}})
-	tx, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgSend}, &txOption)
+	msgSend := bankTypes.NewMsgSend(c.senderAddress(ctx), toAddr, sdk.Coins{sdk.Coin{Denom: gnfdSdkTypes.Denom, Amount: amount}})
+	tx, err := c.broadcastTx(ctx, []sdk.Msg{msgSend}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -196,14 +196,14 @@ func (c *client) MultiTransfer(ctx context.Context, details []types.TransferDeta
 		sum = sum.Add(details[i].Amount)
 	}
 	in := bankTypes.Input{
-		Address: c.MustGetDefaultAccount().GetAddress().String(),
+		Address: c.senderAddress(ctx).String(),
 		Coins:   []sdk.Coin{{Denom: denom, Amount: sum}},
 	}
 	msg := &bankTypes.MsgMultiSend{
 		Inputs:  []bankTypes.Input{in},
 		Outputs: outputs,
 	}
-	tx, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
+	tx, err := c.broadcastTx(ctx, []sdk.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// Authz interface defines the functions of granting other accounts to send msgs on behalf of the account.
+// The grantee sends the granted msgs with the context returned by WithExecAs.
+type Authz interface {
+	GrantAuthorization(ctx context.Context, granteeAddr string, authorization authz.Authorization, opts types.GrantAuthorizationOptions) (string, error)
+	RevokeAuthorization(ctx context.Context, granteeAddr string, msgTypeURL string, txOption gnfdSdkTypes.TxOption) (string, error)
+	QueryGrants(ctx context.Context, granterAddr, granteeAddr, msgTypeURL string) ([]*authz.GrantAuthorization, error)
+}
+
+// GrantAuthorization grants the grantee to send the msgs allowed by the authorization on behalf of the account,
+// e.g. authz.NewGenericAuthorization(sdk.MsgTypeURL(&storageTypes.MsgCreateObject{})) allows creating objects
+func (c *client) GrantAuthorization(ctx context.Context, granteeAddr string, authorization authz.Authorization, opts types.GrantAuthorizationOptions) (string, error) {
+	grantee, err := sdk.AccAddressFromHexUnsafe(granteeAddr)
+	if err != nil {
+		return "", err
+	}
+	msgGrant, err := authz.NewMsgGrant(c.senderAddress(ctx), grantee, authorization, opts.Expiration)
+	if err != nil {
+		return "", err
+	}
+	if err = msgGrant.ValidateBasic(); err != nil {
+		return "", err
+	}
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{msgGrant}, &opts.TxOption)
+	if err != nil {
+		return "", err
+	}
+	return resp.TxResponse.TxHash, nil
+}
+
+// RevokeAuthorization revokes the grant of the msg type from the grantee
+func (c *client) RevokeAuthorization(ctx context.Context, granteeAddr string, msgTypeURL string, txOption gnfdSdkTypes.TxOption) (string, error) {
+	grantee, err := sdk.AccAddressFromHexUnsafe(granteeAddr)
+	if err != nil {
+		return "", err
+	}
+	msgRevoke := authz.NewMsgRevoke(c.senderAddress(ctx), grantee, msgTypeURL)
+	if err = msgRevoke.ValidateBasic(); err != nil {
+		return "", err
+	}
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{&msgRevoke}, &txOption)
+	if err != nil {
+		return "", err
+	}
+	return resp.TxResponse.TxHash, nil
+}
+
+// QueryGrants returns the grants given by the granter to the grantee, the msgTypeURL filters the grants of
+// the msg type if it is not empty. Either the granter or the grantee can be empty to query all the grants
+// given by the granter or to the grantee, in which case the msgTypeURL is ignored.
+func (c *client) QueryGrants(ctx context.Context, granterAddr, granteeAddr, msgTypeURL string) ([]*authz.GrantAuthorization, error) {
+	var (
+		granter, grantee sdk.AccAddress
+		err              error
+	)
+	if granterAddr != "" {
+		if granter, err = sdk.AccAddressFromHexUnsafe(granterAddr); err != nil {
+			return nil, err
+		}
+	}
+	if granteeAddr != "" {
+		if grantee, err = sdk.AccAddressFromHexUnsafe(granteeAddr); err != nil {
+			return nil, err
+		}
+	}
+
+	if granter.Empty() && grantee.Empty() {
+		return nil, errors.New("either the granter or the grantee must be given")
+	}
+
+	var (
+		grants  []*authz.GrantAuthorization
+		nextKey []byte
+	)
+	for {
+		pageReq := &query.PageRequest{Key: nextKey}
+		var pageRes *query.PageResponse
+		switch {
+		case grantee.Empty():
+			resp, err := c.chainClient.AuthzQueryClient.GranterGrants(ctx, &authz.QueryGranterGrantsRequest{
+				Granter: granter.String(), Pagination: pageReq})
+			if err != nil {
+				return nil, err
+			}
+			grants = append(grants, resp.Grants...)
+			pageRes = resp.Pagination
+		case granter.Empty():
+			resp, err := c.chainClient.AuthzQueryClient.GranteeGrants(ctx, &authz.QueryGranteeGrantsRequest{
+				Grantee: grantee.String(), Pagination: pageReq})
+			if err != nil {
+				return nil, err
+			}
+			grants = append(grants, resp.Grants...)
+			pageRes = resp.Pagination
+		default:
+			resp, err := c.chainClient.AuthzQueryClient.Grants(ctx, &authz.QueryGrantsRequest{
+				Granter: granter.String(), Grantee: grantee.String(), MsgTypeUrl: msgTypeURL, Pagination: pageReq})
+			if err != nil {
+				return nil, err
+			}
+			for _, grant := range resp.Grants {
+				grants = append(grants, &authz.GrantAuthorization{
+					Granter:       granter.String(),
+					Grantee:       grantee.String(),
+					Authorization: grant.Authorization,
+					Expiration:    grant.Expiration,
+				})
+			}
+			pageRes = resp.Pagination
+		}
+		if pageRes == nil || len(pageRes.NextKey) == 0 {
+			return grants, nil
+		}
+		nextKey = pageRes.NextKey
+	}
+}
+
+type execAsContextKey struct{}
+
+// WithExecAs returns a copy of ctx with which the write APIs act on behalf of the granter. The msgs are built
+// with the granter as the sender, and wrapped in a MsgExec which is signed and paid by the account of ctx,
+// so the account must have been granted the msgs by GrantAuthorization of the granter.
+func WithExecAs(ctx context.Context, granter sdk.AccAddress) context.Context {
+	return context.WithValue(ctx, execAsContextKey{}, granter)
+}
+
+func execAsFromContext(ctx context.Context) (sdk.AccAddress, bool) {
+	granter, ok := ctx.Value(execAsContextKey{}).(sdk.AccAddress)
+	return granter, ok && !granter.Empty()
+}
+
+// senderAddress returns the address the msgs are sent from, which is the granter if ctx executes on behalf of
+// it, or the address of the account of ctx
+func (c *client) senderAddress(ctx context.Context) sdk.AccAddress {
+	if granter, ok := execAsFromContext(ctx); ok {
+		return granter
+	}
+	return c.mustGetAccount(ctx).GetAddress()
+}
+
+// wrapExecAs wraps the msgs in a MsgExec of the account of ctx if ctx executes on behalf of a granter
+func (c *client) wrapExecAs(ctx context.Context, msgs []sdk.Msg) ([]sdk.Msg, error) {
+	if _, ok := execAsFromContext(ctx); !ok {
+		return msgs, nil
+	}
+	grantee, err := c.getAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	msgExec := authz.NewMsgExec(grantee.GetAddress(), msgs)
+	return []sdk.Msg{&msgExec}, nil
+}
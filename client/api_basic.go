@@ -2,19 +2,28 @@ package client
 
 import (
 	"context"
-	"strings"
+	"fmt"
 	"time"
 
 	"cosmossdk.io/errors"
 	"github.com/bnb-chain/greenfield/sdk/types"
 	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/rs/zerolog/log"
 	"github.com/tendermint/tendermint/proto/tendermint/p2p"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gosdktypes "github.com/bnb-chain/greenfield-go-sdk/types"
 )
 
+// txTypeURL is the type URL of the txs indexed by the node
+const txTypeURL = "/cosmos.tx.v1beta1.Tx"
+
 // Basic interface defines basic functions of greenfield client.
 type Basic interface {
 	GetNodeInfo(ctx context.Context) (*p2p.DefaultNodeInfo, *tmservice.VersionInfo, error)
@@ -27,10 +36,14 @@ type Basic interface {
 
 	WaitForBlockHeight(ctx context.Context, height int64) error
 	WaitForTx(ctx context.Context, hash string) (*sdk.TxResponse, error)
+	WaitForTxResult(ctx context.Context, hash string) (*gosdktypes.TxResult, error)
+	WaitForTxSuccess(ctx context.Context, hash string) (*gosdktypes.TxResult, error)
+	SearchTxs(ctx context.Context, query gosdktypes.TxQuery) ([]*gosdktypes.TxResult, int, error)
 	WaitForNBlocks(ctx context.Context, n int64) error
 	WaitForNextBlock(ctx context.Context) error
 
 	SimulateTx(ctx context.Context, msgs []sdk.Msg, txOpt types.TxOption, opts ...grpc.CallOption) (*tx.SimulateResponse, error)
+	EstimateFee(ctx context.Context, msgs []sdk.Msg, txOpt types.TxOption) (*gosdktypes.GasEstimate, error)
 	SimulateRawTx(ctx context.Context, txBytes []byte, opts ...grpc.CallOption) (*tx.SimulateResponse, error)
 	BroadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt types.TxOption, opts ...grpc.CallOption) (*tx.BroadcastTxResponse, error)
 	BroadcastRawTx(ctx context.Context, txBytes []byte, sync bool) (*sdk.TxResponse, error)
@@ -50,7 +63,11 @@ func (c *client) GetNodeInfo(ctx context.Context) (*p2p.DefaultNodeInfo, *tmserv
 // It takes a context, transaction bytes, and a sync boolean.
 // If sync is true, the transaction is broadcast synchronously.
 // If sync is false, the transaction is broadcast asynchronously.
+// The txs signed by a multisig account are rejected with types.ErrMultisigNotSupported.
 func (c *client) BroadcastRawTx(ctx context.Context, txBytes []byte, sync bool) (*sdk.TxResponse, error) {
+	if err := checkRawTx(txBytes); err != nil {
+		return nil, err
+	}
 	var mode tx.BroadcastMode
 	if sync {
 		mode = tx.BroadcastMode_BROADCAST_MODE_SYNC
@@ -64,10 +81,22 @@ func (c *client) BroadcastRawTx(ctx context.Context, txBytes []byte, sync bool)
 	return broadcastTxResponse.TxResponse, nil
 }
 
+// checkRawTx rejects the txs the chain is known to reject, the bytes which can not be decoded are left to the chain
+func checkRawTx(txBytes []byte) error {
+	if isMultisig, err := gosdktypes.IsMultisigTx(txBytes); err == nil && isMultisig {
+		return gosdktypes.ErrMultisigNotSupported
+	}
+	return nil
+}
+
 // SimulateRawTx simulates the execution of a raw transaction on the blockchain without broadcasting it to the network.
 // It takes a context, transaction bytes, and any additional gRPC call options.
 // It returns a SimulateResponse object and an error (if any).
+// The txs signed by a multisig account are rejected with types.ErrMultisigNotSupported.
 func (c *client) SimulateRawTx(ctx context.Context, txBytes []byte, opts ...grpc.CallOption) (*tx.SimulateResponse, error) {
+	if err := checkRawTx(txBytes); err != nil {
+		return nil, err
+	}
 	simulateResponse, err := c.chainClient.TxClient.Simulate(
 		ctx,
 		&tx.SimulateRequest{
@@ -96,15 +125,30 @@ func (c *client) GetLatestBlock(ctx context.Context) (*tmservice.Block, error) {
 func (c *client) GetLatestBlockHeight(ctx context.Context) (int64, error) {
 	resp, err := c.GetLatestBlock(ctx)
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 	return resp.Header.Height, nil
 }
 
 // WaitForBlockHeight waits until block height h is committed, or returns an
-// error if ctx is canceled.
+// error if ctx is canceled. The new blocks are notified by the websocket of the
+// node if it is enabled, and polled every second otherwise.
 func (c *client) WaitForBlockHeight(ctx context.Context, h int64) error {
-	ticker := time.NewTicker(time.Second)
+	pollInterval := time.Second
+	var notified <-chan struct{}
+	if c.events != nil {
+		var (
+			cancel func()
+			err    error
+		)
+		if notified, cancel, err = c.events.waitHeight(h); err == nil {
+			defer cancel()
+			pollInterval = wsPollInterval
+		} else {
+			log.Debug().Msg(fmt.Sprintf("poll the block height instead of the websocket: %s", err))
+		}
+	}
+	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 
 	for {
@@ -118,10 +162,11 @@ func (c *client) WaitForBlockHeight(ctx context.Context, h int64) error {
 		select {
 		case <-ctx.Done():
 			return errors.Wrap(ctx.Err(), "timeout exceeded waiting for block")
+		case <-notified:
+			return nil
 		case <-ticker.C:
 		}
 	}
-
 }
 
 // WaitForNextBlock waits until next block is committed.
@@ -141,13 +186,24 @@ func (c *client) WaitForNBlocks(ctx context.Context, n int64) error {
 	return c.WaitForBlockHeight(ctx, start.Header.Height+n)
 }
 
-// WaitForTx requests the tx from hash, if not found, waits for next block and
-// tries again. Returns an error if ctx is canceled.
+// WaitForTx requests the tx from hash, if not found, waits for the tx to be
+// included and tries again. The tx is notified by the websocket of the node if it
+// is enabled, and requested at every new block otherwise. Returns an error
+// if ctx is canceled.
 func (c *client) WaitForTx(ctx context.Context, hash string) (*sdk.TxResponse, error) {
+	if c.events != nil {
+		notified, cancel, err := c.events.waitTx(hash)
+		if err == nil {
+			defer cancel()
+			return c.waitForTxEvent(ctx, hash, notified)
+		}
+		log.Debug().Msg(fmt.Sprintf("poll the tx instead of the websocket: %s", err))
+	}
+
 	for {
 		txResponse, err := c.chainClient.TxClient.GetTx(ctx, &tx.GetTxRequest{Hash: hash})
 		if err != nil {
-			if strings.Contains(err.Error(), "not found") {
+			if status.Code(err) == codes.NotFound {
 				// Tx not found, wait for next block and try again
 				err := c.WaitForNextBlock(ctx)
 				if err != nil {
@@ -162,16 +218,134 @@ func (c *client) WaitForTx(ctx context.Context, hash string) (*sdk.TxResponse, e
 	}
 }
 
+// waitForTxEvent requests the tx until it is found, the tx is requested again once it is notified, or after
+// wsPollInterval in case the event is missed
+func (c *client) waitForTxEvent(ctx context.Context, hash string, notified <-chan struct{}) (*sdk.TxResponse, error) {
+	ticker := time.NewTicker(wsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		txResponse, err := c.chainClient.TxClient.GetTx(ctx, &tx.GetTxRequest{Hash: hash})
+		if err == nil {
+			return txResponse.TxResponse, nil
+		}
+		if status.Code(err) != codes.NotFound {
+			return nil, errors.Wrapf(err, "fetching tx '%s'", hash)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "timeout exceeded waiting for tx")
+		case <-notified:
+			// the tx is included, but it may not be indexed yet
+			notified = nil
+			ticker.Reset(txIndexRetryInterval)
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForTxResult waits for the tx as WaitForTx does, and returns its result with the typed events decoded
+func (c *client) WaitForTxResult(ctx context.Context, hash string) (*gosdktypes.TxResult, error) {
+	txResponse, err := c.WaitForTx(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return gosdktypes.NewTxResult(txResponse)
+}
+
+// WaitForTxSuccess waits for the tx as WaitForTxResult does, and returns an error if the tx fails, along with its
+// result
+func (c *client) WaitForTxSuccess(ctx context.Context, hash string) (*gosdktypes.TxResult, error) {
+	txResult, err := c.WaitForTxResult(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if txResult.Code != 0 {
+		return txResult, fmt.Errorf("tx %s failed with code %d: %s", txResult.TxHash, txResult.Code, txResult.RawLog)
+	}
+	return txResult, nil
+}
+
+// SearchTxs returns a page of the txs meeting the conditions of query with their msgs and typed events decoded,
+// and the total number of the txs meeting the conditions. The failed txs are returned as well, with a non-zero Code.
+func (c *client) SearchTxs(ctx context.Context, query gosdktypes.TxQuery) ([]*gosdktypes.TxResult, int, error) {
+	q, err := query.Query()
+	if err != nil {
+		return nil, 0, err
+	}
+	page, limit := query.Page, query.Limit
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = gosdktypes.DefaultTxQueryLimit
+	}
+	orderBy := "asc"
+	if query.Descending {
+		orderBy = "desc"
+	}
+	searchResult, err := c.tmClient.TxSearch(ctx, q, false, &page, &limit, orderBy)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	txResults := make([]*gosdktypes.TxResult, 0, len(searchResult.Txs))
+	for _, resultTx := range searchResult.Txs {
+		txResponse := sdk.NewResponseResultTx(resultTx, &codectypes.Any{TypeUrl: txTypeURL, Value: resultTx.Tx}, "")
+		txResult, err := gosdktypes.NewTxResult(txResponse)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to decode tx %s: %w", txResponse.TxHash, err)
+		}
+		txResults = append(txResults, txResult)
+	}
+	return txResults, searchResult.TotalCount, nil
+}
+
+type txResultContextKey struct{}
+
+// WithTxResult returns a copy of ctx with which the write APIs wait for their tx to be included in a block, and
+// fill its result into result. The APIs return an error if the tx fails, along with the filled result.
+func WithTxResult(ctx context.Context, result *gosdktypes.TxResult) context.Context {
+	return context.WithValue(ctx, txResultContextKey{}, result)
+}
+
+func txResultFromContext(ctx context.Context) (*gosdktypes.TxResult, bool) {
+	result, ok := ctx.Value(txResultContextKey{}).(*gosdktypes.TxResult)
+	return result, ok && result != nil
+}
+
+// waitForTxResult waits for the broadcast tx and fills its result into the tx result of ctx
+func (c *client) waitForTxResult(ctx context.Context, result *gosdktypes.TxResult, resp *tx.BroadcastTxResponse) error {
+	if resp.TxResponse.Code != 0 {
+		return fmt.Errorf("tx %s failed with code %d: %s", resp.TxResponse.TxHash, resp.TxResponse.Code, resp.TxResponse.RawLog)
+	}
+	txResult, err := c.WaitForTxSuccess(ctx, resp.TxResponse.TxHash)
+	if txResult != nil {
+		*result = *txResult
+	}
+	return err
+}
+
 // BroadcastTx broadcasts a transaction containing the provided messages to the chain.
 // The function returns a pointer to a BroadcastTxResponse and any error that occurred during the operation.
 func (c *client) BroadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt types.TxOption, opts ...grpc.CallOption) (*tx.BroadcastTxResponse, error) {
-	return c.chainClient.BroadcastTx(ctx, msgs, &txOpt, opts...)
+	return c.broadcastTx(ctx, msgs, &txOpt, opts...)
 }
 
 // SimulateTx simulates a transaction containing the provided messages on the chain.
 // The function returns a pointer to a SimulateResponse and any error that occurred during the operation.
 func (c *client) SimulateTx(ctx context.Context, msgs []sdk.Msg, txOpt types.TxOption, opts ...grpc.CallOption) (*tx.SimulateResponse, error) {
-	return c.chainClient.SimulateTx(ctx, msgs, &txOpt, opts...)
+	return c.simulateTx(ctx, msgs, &txOpt, opts...)
+}
+
+// EstimateFee simulates a transaction containing the provided messages, and returns the gas limit and the fee
+// derived by the gas policy of ctx, which are what the transaction would take if it was sent with ctx.
+func (c *client) EstimateFee(ctx context.Context, msgs []sdk.Msg, txOpt types.TxOption) (*gosdktypes.GasEstimate, error) {
+	simulateRes, err := c.simulateTx(ctx, msgs, &txOpt)
+	if err != nil {
+		return nil, err
+	}
+	return c.gasPolicy(ctx).Estimate(simulateRes.GasInfo)
 }
 
 // GetSyncing retrieves the syncing status of the node. If true, means the node is catching up the latest block.
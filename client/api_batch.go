@@ -0,0 +1,324 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"cosmossdk.io/math"
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/gogo/protobuf/proto"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// batchMsgOverhead is the size reserved for the fee, the signature and the other parts of a batch tx besides the msgs
+const batchMsgOverhead = 1024
+
+// Batch interface defines the functions of sending many msgs in few txs
+type Batch interface {
+	NewTxBatch() *TxBatch
+}
+
+// TxBatch queues the msgs of different operations and sends them in as few txs as the limits allow. The ante
+// handler of greenfield rejects the txs of more than one msg (SingleMessageDecorator of app/ante), so the msgs
+// are sent in a MsgExec of the account itself, which needs no grant.
+// The msgs of a batch are sent in order, and a msg can rely on the msgs queued before it, e.g. the policy of a
+// bucket created by the same batch.
+type TxBatch struct {
+	c    *client
+	mu   sync.Mutex
+	msgs []sdk.Msg
+	// sendMu serializes Send, so the msgs at the head of the queue are of the chunk being sent
+	sendMu sync.Mutex
+}
+
+// NewTxBatch returns an empty batch of the client
+func (c *client) NewTxBatch() *TxBatch {
+	return &TxBatch{c: c}
+}
+
+type batchContextKey struct{}
+
+// batchCapture collects the msgs which the write APIs broadcast with a batch context
+type batchCapture struct {
+	msgs []sdk.Msg
+}
+
+func batchCaptureFromContext(ctx context.Context) (*batchCapture, bool) {
+	capture, ok := ctx.Value(batchContextKey{}).(*batchCapture)
+	return capture, ok && capture != nil
+}
+
+// queue runs the write API op with a context capturing the msgs it broadcasts, and queues the msgs
+func (b *TxBatch) queue(ctx context.Context, op func(ctx context.Context) error) error {
+	capture := &batchCapture{}
+	if err := op(context.WithValue(ctx, batchContextKey{}, capture)); err != nil {
+		return err
+	}
+	b.AddMsgs(capture.msgs...)
+	return nil
+}
+
+// Len returns the number of the queued msgs
+func (b *TxBatch) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.msgs)
+}
+
+// AddMsgs queues the msgs which are built by the caller
+func (b *TxBatch) AddMsgs(msgs ...sdk.Msg) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.msgs = append(b.msgs, msgs...)
+}
+
+// CreateBucket queues the msg of creating the bucket, the approval of the primary SP is requested right away
+func (b *TxBatch) CreateBucket(ctx context.Context, bucketName string, primaryAddr string, opts types.CreateBucketOptions) error {
+	return b.queue(ctx, func(ctx context.Context) error {
+		_, err := b.c.CreateBucket(ctx, bucketName, primaryAddr, opts)
+		return err
+	})
+}
+
+// PutBucketPolicy queues the msg of applying the bucket policy to the principal
+func (b *TxBatch) PutBucketPolicy(ctx context.Context, bucketName string, principalStr types.Principal,
+	statements []*permTypes.Statement, opt types.PutPolicyOption) error {
+	return b.queue(ctx, func(ctx context.Context) error {
+		_, err := b.c.PutBucketPolicy(ctx, bucketName, principalStr, statements, opt)
+		return err
+	})
+}
+
+// CreateGroup queues the msg of creating the group
+func (b *TxBatch) CreateGroup(ctx context.Context, groupName string, opt types.CreateGroupOptions) error {
+	return b.queue(ctx, func(ctx context.Context) error {
+		_, err := b.c.CreateGroup(ctx, groupName, opt)
+		return err
+	})
+}
+
+// UpdateGroupMember queues the msg of adding or removing members from the group
+func (b *TxBatch) UpdateGroupMember(ctx context.Context, groupName string, groupOwnerAddr string,
+	addAddresses, removeAddresses []string, opts types.UpdateGroupMemberOption) error {
+	return b.queue(ctx, func(ctx context.Context) error {
+		_, err := b.c.UpdateGroupMember(ctx, groupName, groupOwnerAddr, addAddresses, removeAddresses, opts)
+		return err
+	})
+}
+
+// Transfer queues the msg of transferring the amount to the address
+func (b *TxBatch) Transfer(ctx context.Context, toAddress string, amount math.Int) error {
+	return b.queue(ctx, func(ctx context.Context) error {
+		_, err := b.c.Transfer(ctx, toAddress, amount, gnfdSdkTypes.TxOption{})
+		return err
+	})
+}
+
+// Send sends the queued msgs. The msgs are split into txs by the limits of opt, the gas of a tx is estimated by
+// simulating it unless opt.TxOption.NoSimulate is set. The txs are sent one by one, each after the previous one
+// is committed, and the msgs of a tx are removed from the batch once it is committed successfully. The results
+// of the msgs are returned in the order of the msgs; if a tx fails, the results of the msgs sent before it are
+// returned with the error, and the msgs not sent yet are kept in the batch, so Send can be called again.
+func (b *TxBatch) Send(ctx context.Context, opt types.TxBatchOption) ([]*types.BatchMsgResult, error) {
+	if _, ok := generateOnlyFromContext(ctx); ok {
+		return nil, errors.New("a batch can't be sent in the generate only mode")
+	}
+	if _, ok := dryRunFromContext(ctx); ok {
+		return nil, errors.New("a batch can't be sent in the dry-run mode")
+	}
+	b.sendMu.Lock()
+	defer b.sendMu.Unlock()
+	b.mu.Lock()
+	msgs := b.msgs
+	b.mu.Unlock()
+
+	sender, err := b.c.getAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the msgs on behalf of a granter are wrapped in the MsgExec of the grantee by broadcastTx
+	_, execAs := execAsFromContext(ctx)
+	wrap := func(chunk []sdk.Msg) []sdk.Msg {
+		if execAs {
+			return chunk
+		}
+		msgExec := authz.NewMsgExec(sender.GetAddress(), chunk)
+		return []sdk.Msg{&msgExec}
+	}
+	simulate := func(chunk []sdk.Msg) (uint64, error) {
+		simulateRes, err := b.c.simulateTx(ctx, wrap(chunk), &opt.TxOption)
+		if err != nil {
+			return 0, err
+		}
+		return simulateRes.GasInfo.GetGasUsed(), nil
+	}
+	if opt.TxOption.NoSimulate {
+		simulate = nil
+	}
+
+	results := make([]*types.BatchMsgResult, 0, len(msgs))
+	for len(msgs) > 0 {
+		n, err := nextBatchChunk(msgs, opt, simulate)
+		if err != nil {
+			return results, err
+		}
+		chunk := msgs[:n]
+		resp, err := b.c.broadcastTx(ctx, wrap(chunk), &opt.TxOption)
+		if err != nil {
+			return results, err
+		}
+		if resp.TxResponse.Code != 0 {
+			return results, fmt.Errorf("batch tx %s failed with code %d: %s", resp.TxResponse.TxHash, resp.TxResponse.Code, resp.TxResponse.RawLog)
+		}
+		txResp, err := b.c.WaitForTx(ctx, resp.TxResponse.TxHash)
+		if err != nil {
+			return results, err
+		}
+		if txResp.Code != 0 {
+			return results, fmt.Errorf("batch tx %s failed with code %d: %s", txResp.TxHash, txResp.Code, txResp.RawLog)
+		}
+		b.mu.Lock()
+		b.msgs = b.msgs[n:]
+		b.mu.Unlock()
+		chunkResults, err := decodeBatchResults(txResp, chunk)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, chunkResults...)
+		msgs = msgs[n:]
+	}
+	return results, nil
+}
+
+// nextBatchChunk returns the number of the msgs sent in the next tx. The chunk is the longest prefix within the
+// size and number limits, shortened by a binary search if its simulation fails or it exceeds the max gas, so a
+// chunk takes O(log n) simulations. The simulation is skipped if simulate is nil.
+func nextBatchChunk(msgs []sdk.Msg, opt types.TxBatchOption, simulate func(chunk []sdk.Msg) (uint64, error)) (int, error) {
+	maxBytes := opt.MaxBytesPerTx
+	if maxBytes == 0 {
+		maxBytes = types.DefaultBatchMaxBytesPerTx
+	}
+
+	limit := len(msgs)
+	if opt.MaxMsgsPerTx > 0 && opt.MaxMsgsPerTx < limit {
+		limit = opt.MaxMsgsPerTx
+	}
+	size := batchMsgOverhead
+	for n := 1; n <= limit; n++ {
+		// the size of the msg wrapped in an Any of the MsgExec
+		msgExec := authz.NewMsgExec(nil, msgs[n-1:n])
+		size += msgExec.Size()
+		if size > maxBytes {
+			if n == 1 {
+				return 0, fmt.Errorf("the msg %s exceeds the max size of a tx", sdk.MsgTypeURL(msgs[0]))
+			}
+			limit = n - 1
+			break
+		}
+	}
+	if simulate == nil {
+		return limit, nil
+	}
+
+	fits := func(n int) error {
+		gas, err := simulate(msgs[:n])
+		if err != nil {
+			return err
+		}
+		if opt.MaxGasPerTx > 0 && gas > opt.MaxGasPerTx {
+			return fmt.Errorf("the msg %s needs %d gas which exceeds the max gas of a tx", sdk.MsgTypeURL(msgs[0]), gas)
+		}
+		return nil
+	}
+	err := fits(limit)
+	if err == nil {
+		return limit, nil
+	}
+	if limit == 1 {
+		return 0, err
+	}
+	if err = fits(1); err != nil {
+		return 0, err
+	}
+	// the prefix of good msgs fits and the whole chunk does not, the failing msg is sent in the next tx, where
+	// the error surfaces if it is not resolved by this one
+	good, bad := 1, limit
+	for bad-good > 1 {
+		mid := (good + bad) / 2
+		if fits(mid) == nil {
+			good = mid
+		} else {
+			bad = mid
+		}
+	}
+	return good, nil
+}
+
+// decodeBatchResults maps the responses and the events of the MsgExec of the tx to the msgs of the chunk
+func decodeBatchResults(txResp *sdk.TxResponse, chunk []sdk.Msg) ([]*types.BatchMsgResult, error) {
+	results := make([]*types.BatchMsgResult, len(chunk))
+	for i, msg := range chunk {
+		results[i] = &types.BatchMsgResult{Msg: msg, TxHash: txResp.TxHash, Height: txResp.Height}
+	}
+
+	data, err := hex.DecodeString(txResp.Data)
+	if err != nil {
+		return nil, err
+	}
+	txMsgData := &sdk.TxMsgData{}
+	if err = txMsgData.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	if len(txMsgData.MsgResponses) != 1 {
+		return nil, fmt.Errorf("expect the response of a MsgExec in batch tx %s, got %d responses", txResp.TxHash, len(txMsgData.MsgResponses))
+	}
+	execResp := &authz.MsgExecResponse{}
+	if err = execResp.Unmarshal(txMsgData.MsgResponses[0].Value); err != nil {
+		return nil, err
+	}
+	if len(execResp.Results) != len(chunk) {
+		return nil, fmt.Errorf("expect %d msg results in batch tx %s, got %d", len(chunk), txResp.TxHash, len(execResp.Results))
+	}
+	for i, result := range execResp.Results {
+		results[i].Response = decodeMsgResponse(chunk[i], result)
+	}
+
+	for _, event := range txResp.Events {
+		for _, attr := range event.Attributes {
+			if string(attr.Key) != types.AuthzMsgIndexKey {
+				continue
+			}
+			index, err := strconv.Atoi(string(attr.Value))
+			if err != nil || index < 0 || index >= len(results) {
+				return nil, fmt.Errorf("invalid msg index %q of event %s in batch tx %s", attr.Value, event.Type, txResp.TxHash)
+			}
+			results[index].Events = append(results[index].Events, abci.Event{Type: event.Type, Attributes: event.Attributes})
+			break
+		}
+	}
+	return results, nil
+}
+
+// decodeMsgResponse decodes the response of the msg by the registered response type of the msg, it returns nil
+// if the type is unknown
+func decodeMsgResponse(msg sdk.Msg, data []byte) proto.Message {
+	respType := proto.MessageType(proto.MessageName(msg) + "Response")
+	if respType == nil || respType.Kind() != reflect.Ptr {
+		return nil
+	}
+	resp, ok := reflect.New(respType.Elem()).Interface().(proto.Message)
+	if !ok || proto.Unmarshal(data, resp) != nil {
+		return nil
+	}
+	return resp
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"cosmossdk.io/math"
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func newTestBatchMsgs(t *testing.T, n int) []sdk.Msg {
+	alice, _, err := types.NewAccount("alice")
+	require.NoError(t, err)
+	msgs := make([]sdk.Msg, 0, n)
+	for i := 0; i < n; i++ {
+		msgs = append(msgs, bankTypes.NewMsgSend(alice.GetAddress(), alice.GetAddress(),
+			sdk.NewCoins(sdk.NewCoin(gnfdSdkTypes.Denom, math.NewInt(int64(i+1))))))
+	}
+	return msgs
+}
+
+func TestBatchCapture(t *testing.T) {
+	alice, _, err := types.NewAccount("alice")
+	require.NoError(t, err)
+	bob, _, err := types.NewAccount("bob")
+	require.NoError(t, err)
+
+	c := &client{defaultAccount: alice}
+	batch := c.NewTxBatch()
+	require.NoError(t, batch.Transfer(context.Background(), bob.GetAddress().String(), math.NewInt(5)))
+	require.NoError(t, batch.CreateGroup(context.Background(), "group", types.CreateGroupOptions{}))
+	assert.Error(t, batch.UpdateGroupMember(context.Background(), "group", alice.GetAddress().String(), nil, nil,
+		types.UpdateGroupMemberOption{}))
+	require.Equal(t, 2, batch.Len())
+
+	// the msgs of the batch are sent by the granter on behalf of which the context executes
+	ctx := WithExecAs(context.Background(), bob.GetAddress())
+	require.NoError(t, batch.CreateGroup(ctx, "group", types.CreateGroupOptions{}))
+	require.Equal(t, 3, batch.Len())
+	assert.Equal(t, bob.GetAddress(), batch.msgs[2].GetSigners()[0])
+	_, ok := batch.msgs[0].(*bankTypes.MsgSend)
+	assert.True(t, ok)
+}
+
+func TestNextBatchChunk(t *testing.T) {
+	msgs := newTestBatchMsgs(t, 5)
+
+	n, err := nextBatchChunk(msgs, types.TxBatchOption{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	n, err = nextBatchChunk(msgs, types.TxBatchOption{MaxMsgsPerTx: 2}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	msgExec := authz.NewMsgExec(nil, msgs[:1])
+	n, err = nextBatchChunk(msgs, types.TxBatchOption{MaxBytesPerTx: batchMsgOverhead + 3*msgExec.Size()}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	_, err = nextBatchChunk(msgs, types.TxBatchOption{MaxBytesPerTx: batchMsgOverhead}, nil)
+	assert.Error(t, err)
+
+	// every msg takes 100 gas
+	simulate := func(chunk []sdk.Msg) (uint64, error) {
+		return uint64(100 * len(chunk)), nil
+	}
+	n, err = nextBatchChunk(msgs, types.TxBatchOption{MaxGasPerTx: 350}, simulate)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	_, err = nextBatchChunk(msgs, types.TxBatchOption{MaxGasPerTx: 50}, simulate)
+	assert.Error(t, err)
+
+	// the msg failing the simulation starts the next chunk
+	failing := func(chunk []sdk.Msg) (uint64, error) {
+		if len(chunk) > 3 {
+			return 0, errors.New("simulation failed")
+		}
+		return 0, nil
+	}
+	n, err = nextBatchChunk(msgs, types.TxBatchOption{}, failing)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	_, err = nextBatchChunk(msgs[3:], types.TxBatchOption{}, func([]sdk.Msg) (uint64, error) {
+		return 0, errors.New("simulation failed")
+	})
+	assert.Error(t, err)
+
+	// the chunk is searched in a logarithmic number of simulations
+	msgs = newTestBatchMsgs(t, 100)
+	simulations := 0
+	n, err = nextBatchChunk(msgs, types.TxBatchOption{MaxGasPerTx: 3700}, func(chunk []sdk.Msg) (uint64, error) {
+		simulations++
+		return uint64(100 * len(chunk)), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 37, n)
+	assert.LessOrEqual(t, simulations, 9)
+}
+
+func TestDecodeBatchResults(t *testing.T) {
+	msgs := newTestBatchMsgs(t, 1)
+	msgs = append(msgs, &storageTypes.MsgCreateBucket{BucketName: "bucket"})
+
+	bucketResp := &storageTypes.MsgCreateBucketResponse{BucketId: sdk.NewUint(7)}
+	bucketRespData, err := bucketResp.Marshal()
+	require.NoError(t, err)
+	sendRespData, err := (&bankTypes.MsgSendResponse{}).Marshal()
+	require.NoError(t, err)
+	execResp, err := codectypes.NewAnyWithValue(&authz.MsgExecResponse{Results: [][]byte{sendRespData, bucketRespData}})
+	require.NoError(t, err)
+	data, err := (&sdk.TxMsgData{MsgResponses: []*codectypes.Any{execResp}}).Marshal()
+	require.NoError(t, err)
+
+	txResp := &sdk.TxResponse{
+		TxHash: "HASH",
+		Height: 10,
+		Data:   hex.EncodeToString(data),
+		Events: []abci.Event{
+			{Type: "tx", Attributes: []abci.EventAttribute{{Key: []byte("fee"), Value: []byte("1BNB")}}},
+			{Type: "transfer", Attributes: []abci.EventAttribute{{Key: []byte(types.AuthzMsgIndexKey), Value: []byte("0")}}},
+			{Type: "greenfield.storage.EventCreateBucket", Attributes: []abci.EventAttribute{{Key: []byte(types.AuthzMsgIndexKey), Value: []byte("1")}}},
+		},
+	}
+	results, err := decodeBatchResults(txResp, msgs)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for i, result := range results {
+		assert.Equal(t, msgs[i], result.Msg)
+		assert.Equal(t, "HASH", result.TxHash)
+		assert.Equal(t, int64(10), result.Height)
+		require.Len(t, result.Events, 1)
+	}
+	assert.Equal(t, "transfer", results[0].Events[0].Type)
+	_, ok := results[0].Response.(*bankTypes.MsgSendResponse)
+	assert.True(t, ok)
+	createBucketResp, ok := results[1].Response.(*storageTypes.MsgCreateBucketResponse)
+	require.True(t, ok)
+	assert.Equal(t, sdk.NewUint(7), createBucketResp.BucketId)
+
+	_, err = decodeBatchResults(txResp, msgs[:1])
+	assert.Error(t, err)
+}
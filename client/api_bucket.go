@@ -31,7 +31,8 @@ import (
 type Bucket interface {
 	GetCreateBucketApproval(ctx context.Context, createBucketMsg *storageTypes.MsgCreateBucket) (*storageTypes.MsgCreateBucket, error)
 	// CreateBucket get approval of creating bucket and send createBucket txn to greenfield chain
-	// primaryAddr indicates the HEX-encoded string of the primary storage provider address to which the bucket will be created
+	// primaryAddr indicates the HEX-encoded string of the primary storage provider address to which the bucket will be created,
+	// or types.AutoPrimarySP to select the primary storage provider by SelectStorageProviders
 	CreateBucket(ctx context.Context, bucketName string, primaryAddr string, opts types.CreateBucketOptions) (string, error)
 	DeleteBucket(ctx context.Context, bucketName string, opt types.DeleteBucketOption) (string, error)
 
@@ -81,7 +82,7 @@ func (c *client) GetCreateBucketApproval(ctx context.Context, createBucketMsg *s
 	}
 
 	primarySPAddr := createBucketMsg.GetPrimarySpAddress()
-	endpoint, err := c.getSPUrlByAddr(primarySPAddr)
+	endpoint, err := c.getSPUrlByAddr(ctx, primarySPAddr)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by addr: %s failed, err: %s", primarySPAddr, err.Error()))
 		return nil, err
@@ -109,13 +110,10 @@ func (c *client) GetCreateBucketApproval(ctx context.Context, createBucketMsg *s
 	return &signedMsg, nil
 }
 
-// CreateBucket get approval of creating bucket and send createBucket txn to greenfield chain
+// CreateBucket get approval of creating bucket and send createBucket txn to greenfield chain. If primaryAddr is
+// types.AutoPrimarySP, the primary SP is selected by SelectStorageProviders with opts.SPSelectOption, and the next
+// best SP is tried if one refuses to approve the bucket.
 func (c *client) CreateBucket(ctx context.Context, bucketName string, primaryAddr string, opts types.CreateBucketOptions) (string, error) {
-	address, err := sdk.AccAddressFromHexUnsafe(primaryAddr)
-	if err != nil {
-		return "", err
-	}
-
 	var visibility storageTypes.VisibilityType
 	if opts.Visibility == storageTypes.VISIBILITY_TYPE_UNSPECIFIED {
 		visibility = storageTypes.VISIBILITY_TYPE_PRIVATE // set default visibility type
@@ -124,6 +122,7 @@ func (c *client) CreateBucket(ctx context.Context, bucketName string, primaryAdd
 	}
 
 	var paymentAddr sdk.AccAddress
+	var err error
 	if opts.PaymentAddress != "" {
 		paymentAddr, err = sdk.AccAddressFromHexUnsafe(opts.PaymentAddress)
 		if err != nil {
@@ -131,14 +130,41 @@ func (c *client) CreateBucket(ctx context.Context, bucketName string, primaryAdd
 		}
 	}
 
-	createBucketMsg := storageTypes.NewMsgCreateBucket(c.MustGetDefaultAccount().GetAddress(), bucketName,
-		visibility, address, paymentAddr, 0, nil, opts.ChargedQuota)
+	primaryAddrs := []string{primaryAddr}
+	if primaryAddr == types.AutoPrimarySP {
+		candidates, err := c.SelectStorageProviders(ctx, opts.SPSelectOption)
+		if err != nil {
+			return "", err
+		}
+		primaryAddrs = make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			primaryAddrs = append(primaryAddrs, candidate.Info.GetOperator().String())
+		}
+	}
 
-	err = createBucketMsg.ValidateBasic()
+	// the msg differs among the candidates only in the primary SP, so the invalid input fails once instead of
+	// being sent to every candidate
+	address, err := sdk.AccAddressFromHexUnsafe(primaryAddrs[0])
 	if err != nil {
 		return "", err
 	}
-	signedMsg, err := c.GetCreateBucketApproval(ctx, createBucketMsg)
+	createBucketMsg := storageTypes.NewMsgCreateBucket(c.senderAddress(ctx), bucketName,
+		visibility, address, paymentAddr, 0, nil, opts.ChargedQuota)
+	if err = createBucketMsg.ValidateBasic(); err != nil {
+		return "", err
+	}
+
+	// the next candidate is tried if an SP fails to approve the bucket
+	var signedMsg *storageTypes.MsgCreateBucket
+	for _, operator := range primaryAddrs {
+		msg := *createBucketMsg
+		msg.PrimarySpAddress = operator
+		signedMsg, err = c.GetCreateBucketApproval(ctx, &msg)
+		if err == nil || len(primaryAddrs) == 1 {
+			break
+		}
+		log.Error().Msg(fmt.Sprintf("get the approval of bucket %s from SP %s fail, err: %s", bucketName, operator, err))
+	}
 	if err != nil {
 		return "", err
 	}
@@ -149,7 +175,7 @@ func (c *client) CreateBucket(ctx context.Context, bucketName string, primaryAdd
 		opts.TxOpts = &gnfdsdk.TxOption{Mode: &broadcastMode}
 	}
 
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{signedMsg}, opts.TxOpts)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{signedMsg}, opts.TxOpts)
 	if err != nil {
 		return "", err
 	}
@@ -162,8 +188,14 @@ func (c *client) DeleteBucket(ctx context.Context, bucketName string, opt types.
 	if err := s3util.CheckValidBucketName(bucketName); err != nil {
 		return "", err
 	}
-	delBucketMsg := storageTypes.NewMsgDeleteBucket(c.MustGetDefaultAccount().GetAddress(), bucketName)
-	return c.sendTxn(ctx, delBucketMsg, opt.TxOpts)
+	delBucketMsg := storageTypes.NewMsgDeleteBucket(c.senderAddress(ctx), bucketName)
+	txHash, err := c.sendTxn(ctx, delBucketMsg, opt.TxOpts)
+	if err != nil {
+		return "", err
+	}
+	// the bucket of the same name may be created on another SP
+	c.sps.forgetBucket(bucketName)
+	return txHash, nil
 }
 
 // UpdateBucketVisibility update the visibilityType of bucket
@@ -179,7 +211,7 @@ func (c *client) UpdateBucketVisibility(ctx context.Context, bucketName string,
 		return "", err
 	}
 
-	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.MustGetDefaultAccount().GetAddress(), bucketName, &bucketInfo.ChargedReadQuota, paymentAddr, visibility)
+	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.senderAddress(ctx), bucketName, &bucketInfo.ChargedReadQuota, paymentAddr, visibility)
 	return c.sendTxn(ctx, updateBucketMsg, opt.TxOpts)
 }
 
@@ -191,7 +223,7 @@ func (c *client) UpdateBucketPaymentAddr(ctx context.Context, bucketName string,
 		return "", err
 	}
 
-	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.MustGetDefaultAccount().GetAddress(), bucketName, &bucketInfo.ChargedReadQuota, paymentAddr, bucketInfo.Visibility)
+	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.senderAddress(ctx), bucketName, &bucketInfo.ChargedReadQuota, paymentAddr, bucketInfo.Visibility)
 	return c.sendTxn(ctx, updateBucketMsg, opt.TxOpts)
 }
 
@@ -234,7 +266,7 @@ func (c *client) UpdateBucketInfo(ctx context.Context, bucketName string, opts t
 		chargedReadQuota = bucketInfo.ChargedReadQuota
 	}
 
-	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.MustGetDefaultAccount().GetAddress(), bucketName,
+	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.senderAddress(ctx), bucketName,
 		&chargedReadQuota, paymentAddr, visibility)
 
 	// set the default txn broadcast mode as block mode
@@ -284,7 +316,7 @@ func (c *client) PutBucketPolicy(ctx context.Context, bucketName string, princip
 		return "", err
 	}
 
-	putPolicyMsg := storageTypes.NewMsgPutPolicy(c.MustGetDefaultAccount().GetAddress(), resource.String(),
+	putPolicyMsg := storageTypes.NewMsgPutPolicy(c.senderAddress(ctx), resource.String(),
 		principal, statements, opt.PolicyExpireTime)
 
 	return c.sendPutPolicyTxn(ctx, putPolicyMsg, opt.TxOpts)
@@ -300,7 +332,7 @@ func (c *client) DeleteBucketPolicy(ctx context.Context, bucketName string, prin
 
 	principal := permTypes.NewPrincipalWithAccount(addr)
 
-	return c.sendDelPolicyTxn(ctx, c.MustGetDefaultAccount().GetAddress(), resource, principal, opt.TxOpts)
+	return c.sendDelPolicyTxn(ctx, c.senderAddress(ctx), resource, principal, opt.TxOpts)
 }
 
 // IsBucketPermissionAllowed check if the permission of bucket is allowed to the user.
@@ -349,7 +381,7 @@ func (c *client) GetBucketPolicy(ctx context.Context, bucketName string, princip
 func (c *client) ListBuckets(ctx context.Context) (types.ListBucketsResult, error) {
 	reqMeta := requestMeta{
 		contentSHA256: types.EmptyStringSHA256,
-		userAddress:   c.MustGetDefaultAccount().GetAddress().String(),
+		userAddress:   c.mustGetAccount(ctx).GetAddress().String(),
 	}
 
 	sendOpt := sendOptions{
@@ -357,7 +389,7 @@ func (c *client) ListBuckets(ctx context.Context) (types.ListBucketsResult, erro
 		disableCloseBody: true,
 	}
 
-	endpoint, err := c.getInServiceSP()
+	endpoint, err := c.getInServiceSP(ctx)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("get in-service SP fail %s", err.Error()))
 		return types.ListBucketsResult{}, err
@@ -366,6 +398,8 @@ func (c *client) ListBuckets(ctx context.Context) (types.ListBucketsResult, erro
 	resp, err := c.sendReq(ctx, reqMeta, &sendOpt, endpoint)
 	if err != nil {
 		log.Error().Msg("the list of user's buckets failed: " + err.Error())
+		// the SP is selected again in case it stops serving
+		c.sps.forgetInServiceSP()
 		return types.ListBucketsResult{}, err
 	}
 	defer utils.CloseResponse(resp)
@@ -437,7 +471,7 @@ func (c *client) ListBucketReadRecord(ctx context.Context, bucketName string, op
 		disableCloseBody: true,
 	}
 
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
 		return types.QuotaRecordInfo{}, err
@@ -488,7 +522,7 @@ func (c *client) GetBucketReadQuota(ctx context.Context, bucketName string) (typ
 		disableCloseBody: true,
 	}
 
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
 		return types.QuotaInfo{}, err
@@ -522,9 +556,9 @@ func (c *client) BuyQuotaForBucket(ctx context.Context, bucketName string, targe
 	if err != nil {
 		return "", err
 	}
-	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.MustGetDefaultAccount().GetAddress(), bucketName, &targetQuota, paymentAddr, bucketInfo.Visibility)
+	updateBucketMsg := storageTypes.NewMsgUpdateBucketInfo(c.senderAddress(ctx), bucketName, &targetQuota, paymentAddr, bucketInfo.Visibility)
 
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{updateBucketMsg}, opt.TxOpts)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{updateBucketMsg}, opt.TxOpts)
 	if err != nil {
 		return "", err
 	}
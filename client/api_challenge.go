@@ -58,7 +58,7 @@ func (c *client) GetChallengeInfo(ctx context.Context, info types.ChallengeInfo)
 	}
 
 	bucketName := objectInfo.BucketName
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
 		return types.ChallengeResult{}, err
@@ -104,7 +104,7 @@ func (c *client) SubmitChallenge(ctx context.Context, challengerAddress, spOpera
 		return nil, err
 	}
 	msg := challengetypes.NewMsgSubmit(challenger, spOperator, bucketName, objectName, randomIndex, segmentIndex)
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{msg}, &txOption)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +132,7 @@ func (c *client) Attest(ctx context.Context, submitterAddress, challengerAddress
 	}
 
 	msg := challengetypes.NewMsgAttest(submitter, challengeId, objectId, spOperatorAddress, voteResult, challengerAddress, voteValidatorSet, VoteAggSignature)
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{msg}, &txOption)
 	if err != nil {
 		return nil, err
 	}
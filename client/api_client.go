@@ -15,6 +15,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	hashlib "github.com/bnb-chain/greenfield-common/go/hash"
@@ -26,7 +27,9 @@ import (
 	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
 	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/rs/zerolog/log"
+	chttp "github.com/tendermint/tendermint/rpc/client/http"
 	"google.golang.org/grpc"
 )
 
@@ -43,10 +46,19 @@ type Client interface {
 	Validator
 	Distribution
 	CrossChain
+	Tx
+	Authz
+	FeeGrant
+	Batch
+	EventSubscription
 
 	GetDefaultAccount() (*types.Account, error)
 	SetDefaultAccount(account *types.Account)
+	GetAccountRegister() *types.AccountRegister
 	EnableTrace(outputStream io.Writer, onlyTraceErr bool)
+	// Close stops the websocket of the node enabled by Option.EnableWebsocket, the client polls the node for
+	// the txs and the blocks it waits for afterwards
+	Close() error
 }
 
 // client represents a Greenfield SDK client that can interact with the blockchain
@@ -54,12 +66,28 @@ type Client interface {
 type client struct {
 	// The chain client is used to interact with the blockchain
 	chainClient *sdkclient.GreenfieldClient
+	// The tendermint RPC client of the node, which serves the block results not served by the chain client
+	tmClient *chttp.HTTP
 	// The HTTP client is used to send HTTP requests to the greenfield blockchain and sp
 	httpClient *http.Client
-	// Service provider endpoints
-	spEndpoints map[string]*url.URL
+	// The cache of the SP endpoints and the primary SPs of the buckets
+	sps *spRegistry
 	// The default account to use when sending transactions.
 	defaultAccount *types.Account
+	// mu guards the default account
+	mu sync.RWMutex
+	// The keyring of the named accounts which can be used by the per-call account override
+	accountRegister *types.AccountRegister
+	// The default fee granter of the txs
+	feeGranter sdk.AccAddress
+	// The gas policy of the txs unless the context of a tx sets another one
+	defaultGasPolicy *types.GasPolicy
+	// The retry policy of the requests to SP unless the context of a request sets another one
+	defaultRetryPolicy *types.RetryPolicy
+	// The waiters of the txs and the blocks notified by the websocket, it is nil if the websocket is disabled
+	events *eventWaiter
+	// The sequences of the accounts sending txs by the client
+	nonces *nonceManager
 	// Whether the connection to the blockchain node is secure (HTTPS) or not (HTTP).
 	secure bool
 	// Host is the target sp server hostname，it is the host info in the request which sent to SP
@@ -78,12 +106,35 @@ type Option struct {
 	GrpcDialOption grpc.DialOption
 	// account used to set the default account of client
 	DefaultAccount *types.Account
+	// AccountRegister is the keyring of the named accounts, an empty one is created if it is nil
+	AccountRegister *types.AccountRegister
 	// Secure is a flag that specifies whether the client should use HTTPS or not.
 	Secure bool
 	// Transport is the HTTP transport used to send requests to the storage provider endpoint.
 	Transport http.RoundTripper
 	// Host is the target sp server hostname
 	Host string
+	// FeeGranter pays the fees of the txs sent by the client, unless the TxOption of a tx sets another one
+	FeeGranter sdk.AccAddress
+	// GasPolicy derives the gas limit and the fee of the txs from their simulation, the simulated gas and the min
+	// gas price of the node are used as they are if it is nil
+	GasPolicy *types.GasPolicy
+	// RetryPolicy retries the failed requests to SP, types.DefaultRetryPolicy is used if it is nil
+	RetryPolicy *types.RetryPolicy
+	// SPEndpoints are the static endpoints of the SPs keyed by the HEX-encoded operator addresses, which are used
+	// instead of the endpoints registered on chain, e.g. for the private networks and the tests
+	SPEndpoints map[string]string
+	// SPCacheTTL is the time the SP endpoints and the primary SPs of the buckets are cached for, the expired SP
+	// endpoints are refreshed in the background. DefaultSPCacheTTL is used if it is zero.
+	SPCacheTTL time.Duration
+	// SPNegativeCacheTTL is the time an SP address missing on chain is remembered for, DefaultSPNegativeCacheTTL
+	// is used if it is zero
+	SPNegativeCacheTTL time.Duration
+	// EnableWebsocket makes the client wait for the txs and the blocks by the events subscribed over the
+	// websocket of the node, instead of polling the node. The websocket receives the events of all the txs of
+	// the chain, so it pays off for the clients waiting for many txs. It is connected on the first wait and kept
+	// until Close is called.
+	EnableWebsocket bool
 }
 
 // New - instantiate greenfield chain with chain info, account info and options.
@@ -96,29 +147,60 @@ func New(chainID string, endpoint string, option Option) (Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	if option.DefaultAccount != nil {
-		cc.SetKeyManager(option.DefaultAccount.GetKeyManager())
+	tmClient, err := chttp.New(endpoint, "/websocket")
+	if err != nil {
+		return nil, err
 	}
-
-	c := client{
-		chainClient:    cc,
-		httpClient:     &http.Client{Transport: option.Transport},
-		userAgent:      types.UserAgent,
-		defaultAccount: option.DefaultAccount, // it allows to be nil
-		secure:         option.Secure,
-		host:           option.Host,
+	accountRegister := option.AccountRegister
+	if accountRegister == nil {
+		accountRegister, _ = types.NewAccountRegister()
 	}
 
-	// fetch sp endpoints info from chain
-	spInfo, err := c.getSPUrlList()
+	c := client{
+		chainClient:        cc,
+		tmClient:           tmClient,
+		httpClient:         &http.Client{Transport: option.Transport},
+		userAgent:          types.UserAgent,
+		defaultAccount:     option.DefaultAccount, // it allows to be nil
+		accountRegister:    accountRegister,
+		feeGranter:         option.FeeGranter,
+		defaultGasPolicy:   option.GasPolicy,
+		defaultRetryPolicy: option.RetryPolicy,
+		nonces:             newNonceManager(),
+		secure:             option.Secure,
+		host:               option.Host,
+	}
+
+	if option.EnableWebsocket {
+		c.events = newEventWaiter(endpoint)
+	}
+
+	// the sp endpoints info is fetched from chain on the first storage call, so that the client works without SPs
+	spOverrides, err := c.parseSPEndpoints(option.SPEndpoints)
 	if err != nil {
 		return nil, err
 	}
-
-	c.spEndpoints = spInfo
+	c.sps = newSPRegistry(option.SPCacheTTL, option.SPNegativeCacheTTL, spOverrides, c.getSPUrlList)
 	return &c, nil
 }
 
+// parseSPEndpoints parses the static SP endpoints keyed by the HEX-encoded operator addresses
+func (c *client) parseSPEndpoints(spEndpoints map[string]string) (map[string]*url.URL, error) {
+	overrides := make(map[string]*url.URL, len(spEndpoints))
+	for address, endpoint := range spEndpoints {
+		operator, err := sdk.AccAddressFromHexUnsafe(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SP operator address %s: %w", address, err)
+		}
+		urlInfo, err := utils.GetEndpointURL(endpoint, strings.HasPrefix(endpoint, "https") || c.secure)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint %s of SP %s: %w", endpoint, address, err)
+		}
+		overrides[operator.String()] = urlInfo
+	}
+	return overrides, nil
+}
+
 // EnableTrace support trace error info the request and the response
 func (c *client) EnableTrace(output io.Writer, onlyTraceErr bool) {
 	if output == nil {
@@ -131,77 +213,55 @@ func (c *client) EnableTrace(output io.Writer, onlyTraceErr bool) {
 	c.isTraceEnabled = true
 }
 
-// getSPUrlByBucket route url of the sp from bucket name
-func (c *client) getSPUrlByBucket(bucketName string) (*url.URL, error) {
-	ctx := context.Background()
-	bucketInfo, err := c.HeadBucket(ctx, bucketName)
-	if err != nil {
-		return nil, err
+// Close stops the websocket of the node if it is enabled
+func (c *client) Close() error {
+	if c.events == nil {
+		return nil
 	}
+	return c.events.close()
+}
 
-	primarySP := bucketInfo.GetPrimarySpAddress()
-	if _, ok := c.spEndpoints[primarySP]; ok {
-		return c.spEndpoints[primarySP], nil
-	}
-	// query sp info from chain
-	newSpInfo, err := c.getSPUrlList()
-	if err != nil {
-		return nil, err
+// getSPUrlByBucket route url of the sp from bucket name, the primary SP of the bucket is cached
+func (c *client) getSPUrlByBucket(ctx context.Context, bucketName string) (*url.URL, error) {
+	primarySP, ok := c.sps.primarySP(bucketName)
+	if !ok {
+		bucketInfo, err := c.HeadBucket(ctx, bucketName)
+		if err != nil {
+			return nil, err
+		}
+		primarySP = bucketInfo.GetPrimarySpAddress()
+		c.sps.setPrimarySP(bucketName, primarySP)
 	}
+	return c.sps.endpoint(ctx, primarySP)
+}
 
-	if _, ok := newSpInfo[primarySP]; ok {
-		c.spEndpoints = newSpInfo
-		return newSpInfo[primarySP], nil
+// forgetStaleBucket drops the cached primary SP of the bucket if the SP responds that it does not have the bucket or
+// does not serve it, e.g. after the bucket is deleted and created on another SP by another client
+func (c *client) forgetStaleBucket(bucketName string, err error) {
+	var errResp types.ErrResponse
+	if bucketName != "" && errors.As(err, &errResp) &&
+		(errResp.StatusCode == http.StatusNotFound || errResp.StatusCode == http.StatusForbidden) {
+		c.sps.forgetBucket(bucketName)
 	}
-
-	return nil, fmt.Errorf("the SP endpoint %s not exists on chain", primarySP)
 }
 
 // getSPUrlByAddr route url of the sp from sp address
-func (c *client) getSPUrlByAddr(address string) (*url.URL, error) {
-	if _, ok := c.spEndpoints[address]; ok {
-		return c.spEndpoints[address], nil
-	}
-	// query sp info from chain
-	newSpInfo, err := c.getSPUrlList()
-	if err != nil {
-		return nil, err
-	}
-
-	if _, ok := newSpInfo[address]; ok {
-		c.spEndpoints = newSpInfo
-		return newSpInfo[address], nil
-	}
-
-	return nil, fmt.Errorf("the SP endpoint %s not exists on chain", address)
+func (c *client) getSPUrlByAddr(ctx context.Context, address string) (*url.URL, error) {
+	return c.sps.endpoint(ctx, address)
 }
 
-// getInServiceSP return the first SP endpoint which is in service in SP list
-func (c *client) getInServiceSP() (*url.URL, error) {
-	ctx := context.Background()
-	spList, err := c.ListStorageProviders(ctx, true)
+// getInServiceSP returns the endpoint of the healthy in-service SP responding the fastest, the selected SP is cached
+// for the TTL of the SP cache
+func (c *client) getInServiceSP(ctx context.Context) (*url.URL, error) {
+	if endpoint, ok := c.sps.inServiceSP(); ok {
+		return endpoint, nil
+	}
+	candidates, err := c.SelectStorageProviders(ctx, types.SPSelectOption{Weights: &types.SPScoreWeights{Latency: 1}})
 	if err != nil {
 		return nil, err
 	}
-
-	if len(spList) == 0 {
-		return nil, errors.New("fail to get SP endpoint")
-	}
-
-	var useHttps bool
-	SPEndpoint := spList[0].Endpoint
-	if strings.Contains(SPEndpoint, "https") {
-		useHttps = true
-	} else {
-		useHttps = c.secure
-	}
-
-	urlInfo, urlErr := utils.GetEndpointURL(spList[0].Endpoint, useHttps)
-	if urlErr != nil {
-		return nil, urlErr
-	}
-
-	return urlInfo, nil
+	c.sps.setInServiceSP(candidates[0].Endpoint)
+	return candidates[0].Endpoint, nil
 }
 
 // requestMeta - contains the metadata to construct the http request.
@@ -371,15 +431,6 @@ func (c *client) doAPI(ctx context.Context, req *http.Request, meta requestMeta,
 			return nil, ctx.Err()
 		default:
 		}
-		if urlErr, ok := err.(*url.Error); ok {
-			if strings.Contains(urlErr.Err.Error(), "EOF") {
-				return nil, &url.Error{
-					Op:  urlErr.Op,
-					URL: urlErr.URL,
-					Err: errors.New("Connection closed by foreign host " + urlErr.URL + ". Retry again."),
-				}
-			}
-		}
 		return nil, err
 	}
 	defer func() {
@@ -406,19 +457,39 @@ func (c *client) doAPI(ctx context.Context, req *http.Request, meta requestMeta,
 	return resp, nil
 }
 
-// sendReq sends the message via REST and handles the response
+// sendReq sends the message via REST and handles the response. The failed request is retried by the retry
+// policy of ctx if it can be sent again, and every retry is signed again with a fresh date.
 func (c *client) sendReq(ctx context.Context, metadata requestMeta, opt *sendOptions, endpoint *url.URL) (res *http.Response, err error) {
-	req, err := c.newRequest(ctx, opt.method, metadata, opt.body, opt.txnHash, opt.isAdminApi, endpoint)
-	if err != nil {
-		return nil, err
-	}
+	policy := c.retryPolicy(ctx)
+	rewind, canRetry := requestRewinder(opt)
+	for attempt := 1; ; attempt++ {
+		req, err := c.newRequest(ctx, opt.method, metadata, opt.body, opt.txnHash, opt.isAdminApi, endpoint)
+		if err != nil {
+			return nil, err
+		}
 
-	resp, err := c.doAPI(ctx, req, metadata, !opt.disableCloseBody)
-	if err != nil {
-		log.Error().Msg(fmt.Sprintf("do API error, url: %s, err: %s", req.URL.String(), err))
-		return nil, err
+		resp, err := c.doAPI(ctx, req, metadata, !opt.disableCloseBody)
+		if err == nil {
+			return resp, nil
+		}
+		if !canRetry || attempt >= policy.MaxAttempts || !policy.IsRetryable(err) {
+			log.Error().Msg(fmt.Sprintf("do API error, url: %s, err: %s", req.URL.String(), err))
+			c.forgetStaleBucket(metadata.bucketName, err)
+			return nil, closedConnError(err)
+		}
+		if opt.disableCloseBody {
+			utils.CloseResponse(resp)
+		}
+		if rewindErr := rewind(); rewindErr != nil {
+			log.Error().Msg(fmt.Sprintf("do API error, url: %s, err: %s, rewind the body fail: %s", req.URL.String(), err, rewindErr))
+			return nil, closedConnError(err)
+		}
+		backoff := policy.Backoff(attempt)
+		log.Debug().Msg(fmt.Sprintf("do API error, url: %s, err: %s, retry in %s", req.URL.String(), err, backoff))
+		if !sleepWithContext(ctx, backoff) {
+			return nil, ctx.Err()
+		}
 	}
-	return resp, nil
 }
 
 // generateURL constructs the target request url based on the parameters
@@ -477,8 +548,12 @@ func (c *client) generateURL(bucketName string, objectName string, relativePath
 func (c *client) signRequest(req *http.Request) error {
 	unsignedMsg := httplib.GetMsgToSign(req)
 
+	account, err := c.getAccount(req.Context())
+	if err != nil {
+		return err
+	}
 	// sign the request header info, generate the signature
-	signature, err := c.MustGetDefaultAccount().Sign(unsignedMsg)
+	signature, err := account.Sign(unsignedMsg)
 	if err != nil {
 		return err
 	}
@@ -583,7 +658,7 @@ func (c *client) sendPutPolicyTxn(ctx context.Context, msg *storageTypes.MsgPutP
 		return "", err
 	}
 
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msg}, txOpts)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{msg}, txOpts)
 	if err != nil {
 		return "", err
 	}
@@ -599,7 +674,7 @@ func (c *client) sendDelPolicyTxn(ctx context.Context, operator sdk.AccAddress,
 		return "", err
 	}
 
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{delPolicyMsg}, txOpts)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{delPolicyMsg}, txOpts)
 	if err != nil {
 		return "", err
 	}
@@ -612,7 +687,7 @@ func (c *client) sendTxn(ctx context.Context, msg sdk.Msg, opt *gnfdSdkTypes.TxO
 		return "", err
 	}
 
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msg}, opt)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{msg}, opt)
 	if err != nil {
 		return "", err
 	}
@@ -621,21 +696,141 @@ func (c *client) sendTxn(ctx context.Context, msg sdk.Msg, opt *gnfdSdkTypes.TxO
 
 // GetDefaultAccount returns the account address of default account in client
 func (c *client) GetDefaultAccount() (*types.Account, error) {
-	if c.MustGetDefaultAccount() == nil {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.defaultAccount == nil {
 		return nil, types.ErrorDefaultAccountNotExist
 	}
-	return c.MustGetDefaultAccount(), nil
+	return c.defaultAccount, nil
 }
 
-// SetDefaultAccount will set the default account
+// SetDefaultAccount will set the default account, the requests in flight keep using the account they started with
 func (c *client) SetDefaultAccount(account *types.Account) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.defaultAccount = account
-	c.chainClient.SetKeyManager(account.GetKeyManager())
+}
+
+// GetAccountRegister returns the keyring of the named accounts
+func (c *client) GetAccountRegister() *types.AccountRegister {
+	return c.accountRegister
 }
 
 func (c *client) MustGetDefaultAccount() *types.Account {
-	if c.defaultAccount == nil {
+	account, err := c.GetDefaultAccount()
+	if err != nil {
 		panic("Default account not exist, Use SetDefaultAccount to set ")
 	}
-	return c.defaultAccount
+	return account
+}
+
+type accountContextKey struct{}
+
+// WithAccount returns a copy of ctx carrying the account. The APIs called with the returned context
+// send the transactions and sign the SP requests with the account instead of the default account,
+// so that one client can serve the requests of many accounts concurrently.
+func WithAccount(ctx context.Context, account *types.Account) context.Context {
+	return context.WithValue(ctx, accountContextKey{}, account)
+}
+
+// AccountFromContext returns the account carried by ctx
+func AccountFromContext(ctx context.Context) (*types.Account, bool) {
+	account, ok := ctx.Value(accountContextKey{}).(*types.Account)
+	return account, ok && account != nil
+}
+
+// getAccount returns the account carried by ctx, or the default account if ctx has none
+func (c *client) getAccount(ctx context.Context) (*types.Account, error) {
+	if account, ok := AccountFromContext(ctx); ok {
+		return account, nil
+	}
+	return c.GetDefaultAccount()
+}
+
+// mustGetAccount is like getAccount but panics if there is no account
+func (c *client) mustGetAccount(ctx context.Context) *types.Account {
+	account, err := c.getAccount(ctx)
+	if err != nil {
+		panic("Default account not exist, Use SetDefaultAccount to set or WithAccount to specify")
+	}
+	return account
+}
+
+// getChainClient returns a copy of the chain client which signs the transactions with the account of ctx,
+// the shared chain client is never mutated so that the concurrent calls of different accounts do not race
+func (c *client) getChainClient(ctx context.Context) (*sdkclient.GreenfieldClient, error) {
+	account, err := c.getAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cc := *c.chainClient
+	cc.SetKeyManager(account.GetKeyManager())
+	return &cc, nil
+}
+
+// broadcastTx signs the msgs with the account of ctx and broadcasts the transaction,
+// or only builds the unsigned tx if ctx is generate-only. The msgs are wrapped in a MsgExec if ctx executes
+// on behalf of a granter, and the fees are paid by the fee granter of the client if txOpt sets none. The sequence
+// of the tx is handed out by the nonce manager of the client unless txOpt sets the nonce.
+func (c *client) broadcastTx(ctx context.Context, msgs []sdk.Msg, txOpt *gnfdSdkTypes.TxOption, opts ...grpc.CallOption) (*tx.BroadcastTxResponse, error) {
+	if capture, ok := batchCaptureFromContext(ctx); ok {
+		capture.msgs = append(capture.msgs, msgs...)
+		return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{}}, nil
+	}
+	msgs, err := c.wrapExecAs(ctx, msgs)
+	if err != nil {
+		return nil, err
+	}
+	txOpt = c.withFeeGranter(txOpt)
+	if unsignedTx, ok := generateOnlyFromContext(ctx); ok {
+		return c.generateTx(ctx, unsignedTx, msgs, txOpt)
+	}
+	if result, ok := dryRunFromContext(ctx); ok {
+		return c.dryRunTx(ctx, result, msgs, txOpt)
+	}
+	cc, err := c.getChainClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	broadcast := func(txOpt *gnfdSdkTypes.TxOption) (*tx.BroadcastTxResponse, error) {
+		// the gas is estimated with the sequence of the tx, which the simulation checks
+		txOpt, err := c.withEstimatedGas(ctx, cc, msgs, txOpt)
+		if err != nil {
+			return nil, err
+		}
+		return cc.BroadcastTx(ctx, msgs, txOpt, opts...)
+	}
+	result, waitForResult := txResultFromContext(ctx)
+	if waitForResult && result.TxHash != "" {
+		return nil, errors.New("the tx result context has been used by another tx")
+	}
+
+	var resp *tx.BroadcastTxResponse
+	// the sequence given by the caller is used as it is
+	if txOpt != nil && txOpt.Nonce != 0 {
+		resp, err = broadcast(txOpt)
+	} else {
+		resp, err = c.broadcastTxWithNonce(ctx, broadcast, c.mustGetAccount(ctx).GetAddress(), txOpt)
+	}
+	if err != nil || !waitForResult {
+		return resp, err
+	}
+	if err = c.waitForTxResult(ctx, result, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// simulateTx simulates the transaction of the msgs signed by the account of ctx
+func (c *client) simulateTx(ctx context.Context, msgs []sdk.Msg, txOpt *gnfdSdkTypes.TxOption, opts ...grpc.CallOption) (*tx.SimulateResponse, error) {
+	msgs, err := c.wrapExecAs(ctx, msgs)
+	if err != nil {
+		return nil, err
+	}
+	txOpt = c.withFeeGranter(txOpt)
+	cc, err := c.getChainClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cc.SimulateTx(ctx, msgs, txOpt, opts...)
 }
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	sdkclient "github.com/bnb-chain/greenfield/sdk/client"
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestPerCallAccount(t *testing.T) {
+	alice, _, err := types.NewAccount("alice")
+	require.NoError(t, err)
+	bob, _, err := types.NewAccount("bob")
+	require.NoError(t, err)
+
+	c := &client{chainClient: &sdkclient.GreenfieldClient{}}
+	_, err = c.getAccount(context.Background())
+	assert.ErrorIs(t, err, types.ErrorDefaultAccountNotExist)
+
+	c.SetDefaultAccount(alice)
+	account, err := c.getAccount(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, alice.GetAddress(), account.GetAddress())
+
+	ctx := WithAccount(context.Background(), bob)
+	cc, err := c.getChainClient(ctx)
+	require.NoError(t, err)
+	km, err := cc.GetKeyManager()
+	require.NoError(t, err)
+	assert.Equal(t, bob.GetAddress(), km.GetAddr())
+
+	// the shared chain client is never bound to an account
+	_, err = c.chainClient.GetKeyManager()
+	assert.Error(t, err)
+}
+
+func TestSetDefaultAccountConcurrent(t *testing.T) {
+	alice, _, err := types.NewAccount("alice")
+	require.NoError(t, err)
+	bob, _, err := types.NewAccount("bob")
+	require.NoError(t, err)
+
+	c := &client{chainClient: &sdkclient.GreenfieldClient{}}
+	c.SetDefaultAccount(alice)
+	ctx := WithAccount(context.Background(), bob)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.SetDefaultAccount(alice)
+		}()
+		go func() {
+			defer wg.Done()
+			cc, err := c.getChainClient(ctx)
+			assert.NoError(t, err)
+			km, err := cc.GetKeyManager()
+			assert.NoError(t, err)
+			assert.Equal(t, bob.GetAddress(), km.GetAddr())
+			_, err = c.getAccount(context.Background())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+// countingSigner is a Signer counting the signatures it made
+type countingSigner struct {
+	*types.Account
+	signed int
+}
+
+func (s *countingSigner) Sign(msg []byte) ([]byte, error) {
+	s.signed++
+	return s.Account.Sign(msg)
+}
+
+func TestSignWithSigner(t *testing.T) {
+	local, _, err := types.NewAccount("local")
+	require.NoError(t, err)
+	signer := &countingSigner{Account: local}
+	account, err := types.NewAccountFromSigner("remote", signer)
+	require.NoError(t, err)
+
+	c := &client{chainClient: &sdkclient.GreenfieldClient{}}
+	ctx := WithAccount(context.Background(), account)
+
+	// the SP requests are signed by the signer
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/bucket", nil)
+	require.NoError(t, err)
+	require.NoError(t, c.signRequest(req))
+	assert.Equal(t, 1, signer.signed)
+	assert.Contains(t, req.Header.Get(types.HTTPHeaderAuthorization), "Signature=")
+
+	// the txs are signed by the key manager of the chain client, which is backed by the signer
+	cc, err := c.getChainClient(ctx)
+	require.NoError(t, err)
+	km, err := cc.GetKeyManager()
+	require.NoError(t, err)
+	assert.Equal(t, local.GetAddress(), km.GetAddr())
+	_, err = km.Sign([]byte("tx sign bytes"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, signer.signed)
+}
+
+func TestExecAs(t *testing.T) {
+	alice, _, err := types.NewAccount("alice")
+	require.NoError(t, err)
+	bob, _, err := types.NewAccount("bob")
+	require.NoError(t, err)
+	c := &client{chainClient: &sdkclient.GreenfieldClient{}}
+	c.SetDefaultAccount(bob)
+
+	msgs := []sdk.Msg{storageTypes.NewMsgDeleteBucket(alice.GetAddress(), "bucket")}
+	wrapped, err := c.wrapExecAs(context.Background(), msgs)
+	require.NoError(t, err)
+	assert.Equal(t, msgs, wrapped)
+	assert.Equal(t, bob.GetAddress(), c.senderAddress(context.Background()))
+
+	// bob sends the msgs of alice in the MsgExec signed by bob
+	ctx := WithExecAs(context.Background(), alice.GetAddress())
+	assert.Equal(t, alice.GetAddress(), c.senderAddress(ctx))
+	wrapped, err = c.wrapExecAs(ctx, msgs)
+	require.NoError(t, err)
+	require.Len(t, wrapped, 1)
+	msgExec, ok := wrapped[0].(*authz.MsgExec)
+	require.True(t, ok)
+	assert.Equal(t, []sdk.AccAddress{bob.GetAddress()}, msgExec.GetSigners())
+	execMsgs, err := msgExec.GetMessages()
+	require.NoError(t, err)
+	assert.Equal(t, msgs, execMsgs)
+}
+
+func TestWithFeeGranter(t *testing.T) {
+	granter, _, err := types.NewAccount("granter")
+	require.NoError(t, err)
+	other, _, err := types.NewAccount("other")
+	require.NoError(t, err)
+
+	c := &client{}
+	txOpt := &gnfdSdkTypes.TxOption{Memo: "memo"}
+	assert.Same(t, txOpt, c.withFeeGranter(txOpt))
+
+	c.feeGranter = granter.GetAddress()
+	withGranter := c.withFeeGranter(txOpt)
+	assert.Equal(t, granter.GetAddress(), withGranter.FeeGranter)
+	assert.Equal(t, "memo", withGranter.Memo)
+	// the option of the caller is never mutated
+	assert.True(t, txOpt.FeeGranter.Empty())
+	assert.Equal(t, granter.GetAddress(), c.withFeeGranter(nil).FeeGranter)
+
+	// the fee granter of the tx takes precedence
+	txOpt.FeeGranter = other.GetAddress()
+	assert.Equal(t, other.GetAddress(), c.withFeeGranter(txOpt).FeeGranter)
+}
+
+func TestGasPolicy(t *testing.T) {
+	c := &client{}
+	assert.Nil(t, c.gasPolicy(context.Background()))
+
+	clientPolicy := &types.GasPolicy{GasMultiplier: 1.2}
+	c.defaultGasPolicy = clientPolicy
+	assert.Same(t, clientPolicy, c.gasPolicy(context.Background()))
+
+	// the policy of the call takes precedence
+	callPolicy := &types.GasPolicy{MaxGas: 1000}
+	assert.Same(t, callPolicy, c.gasPolicy(WithGasPolicy(context.Background(), callPolicy)))
+	assert.Same(t, clientPolicy, c.gasPolicy(WithGasPolicy(context.Background(), nil)))
+
+	// the gas given by the caller is used as it is
+	txOpt := &gnfdSdkTypes.TxOption{NoSimulate: true, GasLimit: 100}
+	withGas, err := c.withEstimatedGas(context.Background(), nil, nil, txOpt)
+	require.NoError(t, err)
+	assert.Same(t, txOpt, withGas)
+}
+
+func TestDryRun(t *testing.T) {
+	_, ok := dryRunFromContext(WithDryRun(context.Background(), nil))
+	assert.False(t, ok)
+
+	// a dry-run result holds one tx only
+	alice, _, err := types.NewAccount("alice")
+	require.NoError(t, err)
+	result := &types.DryRunResult{}
+	ctx := WithDryRun(context.Background(), result)
+	c := &client{defaultAccount: alice}
+	_, err = c.CreateGroup(ctx, "", types.CreateGroupOptions{})
+	assert.Error(t, err)
+	assert.Empty(t, result.Msgs)
+
+	result.Msgs = []sdk.Msg{storageTypes.NewMsgDeleteGroup(alice.GetAddress(), "group")}
+	_, err = c.DeleteGroup(ctx, "group", types.DeleteGroupOption{})
+	assert.ErrorContains(t, err, "dry-run")
+}
@@ -29,11 +29,11 @@ type CrossChain interface {
 
 // TransferOut makes a transfer from Greenfield to BSC
 func (c *client) TransferOut(ctx context.Context, toAddress string, amount math.Int, txOption gnfdSdkTypes.TxOption) (*sdk.TxResponse, error) {
-	msgTransferOut := bridgetypes.NewMsgTransferOut(c.MustGetDefaultAccount().GetAddress().String(),
+	msgTransferOut := bridgetypes.NewMsgTransferOut(c.senderAddress(ctx).String(),
 		toAddress,
 		&sdk.Coin{Denom: gnfdSdkTypes.Denom, Amount: amount},
 	)
-	txResp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgTransferOut}, &txOption)
+	txResp, err := c.broadcastTx(ctx, []sdk.Msg{msgTransferOut}, &txOption)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +45,7 @@ func (c *client) Claims(ctx context.Context, srcShainId, destChainId uint32, seq
 	timestamp uint64, payload []byte, voteAddrSet []uint64, aggSignature []byte, txOption gnfdSdkTypes.TxOption) (*sdk.TxResponse, error) {
 
 	msg := oracletypes.NewMsgClaim(
-		c.MustGetDefaultAccount().GetAddress().String(),
+		c.senderAddress(ctx).String(),
 		srcShainId,
 		destChainId,
 		sequence,
@@ -54,7 +54,7 @@ func (c *client) Claims(ctx context.Context, srcShainId, destChainId uint32, seq
 		voteAddrSet,
 		aggSignature)
 
-	txResp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
+	txResp, err := c.broadcastTx(ctx, []sdk.Msg{msg}, &txOption)
 	if err != nil {
 		return nil, err
 	}
@@ -100,8 +100,8 @@ func (c *client) GetCrossChainPackage(ctx context.Context, channelId uint32, seq
 
 // MirrorGroup mirrors the group to BSC as NFT
 func (c *client) MirrorGroup(ctx context.Context, groupId sdkmath.Uint, txOption gnfdSdkTypes.TxOption) (*sdk.TxResponse, error) {
-	msgMirrorGroup := storagetypes.NewMsgMirrorGroup(c.MustGetDefaultAccount().GetAddress(), groupId)
-	txResp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgMirrorGroup}, &txOption)
+	msgMirrorGroup := storagetypes.NewMsgMirrorGroup(c.senderAddress(ctx), groupId)
+	txResp, err := c.broadcastTx(ctx, []sdk.Msg{msgMirrorGroup}, &txOption)
 	if err != nil {
 		return nil, err
 	}
@@ -110,8 +110,8 @@ func (c *client) MirrorGroup(ctx context.Context, groupId sdkmath.Uint, txOption
 
 // MirrorBucket mirrors the bucket to BSC as NFT
 func (c *client) MirrorBucket(ctx context.Context, bucketId sdkmath.Uint, txOption gnfdSdkTypes.TxOption) (*sdk.TxResponse, error) {
-	msgMirrorBucket := storagetypes.NewMsgMirrorBucket(c.MustGetDefaultAccount().GetAddress(), bucketId)
-	txResp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgMirrorBucket}, &txOption)
+	msgMirrorBucket := storagetypes.NewMsgMirrorBucket(c.senderAddress(ctx), bucketId)
+	txResp, err := c.broadcastTx(ctx, []sdk.Msg{msgMirrorBucket}, &txOption)
 	if err != nil {
 		return nil, err
 	}
@@ -120,8 +120,8 @@ func (c *client) MirrorBucket(ctx context.Context, bucketId sdkmath.Uint, txOpti
 
 // MirrorObject mirrors the object to BSC as NFT
 func (c *client) MirrorObject(ctx context.Context, objectId sdkmath.Uint, txOption gnfdSdkTypes.TxOption) (*sdk.TxResponse, error) {
-	msgMirrorBucket := storagetypes.NewMsgMirrorBucket(c.MustGetDefaultAccount().GetAddress(), objectId)
-	txResp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgMirrorBucket}, &txOption)
+	msgMirrorBucket := storagetypes.NewMsgMirrorBucket(c.senderAddress(ctx), objectId)
+	txResp, err := c.broadcastTx(ctx, []sdk.Msg{msgMirrorBucket}, &txOption)
 	if err != nil {
 		return nil, err
 	}
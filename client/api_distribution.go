@@ -21,8 +21,8 @@ func (c *client) SetWithdrawAddress(ctx context.Context, withdrawAddr string, tx
 	if err != nil {
 		return "", err
 	}
-	msg := distrtypes.NewMsgSetWithdrawAddress(c.MustGetDefaultAccount().GetAddress(), withdraw)
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
+	msg := distrtypes.NewMsgSetWithdrawAddress(c.senderAddress(ctx), withdraw)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -31,8 +31,8 @@ func (c *client) SetWithdrawAddress(ctx context.Context, withdrawAddr string, tx
 
 // WithdrawValidatorCommission withdraw accumulated commission by validator
 func (c *client) WithdrawValidatorCommission(ctx context.Context, txOption gnfdsdktypes.TxOption) (string, error) {
-	msg := distrtypes.NewMsgWithdrawValidatorCommission(c.MustGetDefaultAccount().GetAddress())
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
+	msg := distrtypes.NewMsgWithdrawValidatorCommission(c.senderAddress(ctx))
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -45,8 +45,8 @@ func (c *client) WithdrawDelegatorReward(ctx context.Context, validatorAddr stri
 	if err != nil {
 		return "", err
 	}
-	msg := distrtypes.NewMsgWithdrawDelegatorReward(c.MustGetDefaultAccount().GetAddress(), validator)
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
+	msg := distrtypes.NewMsgWithdrawDelegatorReward(c.senderAddress(ctx), validator)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -55,8 +55,8 @@ func (c *client) WithdrawDelegatorReward(ctx context.Context, validatorAddr stri
 
 // FundCommunityPool sends coins directly from the sender to the community pool.
 func (c *client) FundCommunityPool(ctx context.Context, amount math.Int, txOption gnfdsdktypes.TxOption) (string, error) {
-	msg := distrtypes.NewMsgFundCommunityPool(sdk.Coins{sdk.Coin{Denom: gnfdsdktypes.Denom, Amount: amount}}, c.MustGetDefaultAccount().GetAddress())
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msg}, &txOption)
+	msg := distrtypes.NewMsgFundCommunityPool(sdk.Coins{sdk.Coin{Denom: gnfdsdktypes.Denom, Amount: amount}}, c.senderAddress(ctx))
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
 	}
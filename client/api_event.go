@@ -0,0 +1,176 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// subscribeRetryInterval is the wait before fetching the events of a block again after a transient error
+const subscribeRetryInterval = 3 * time.Second
+
+// EventSubscription interface defines the functions of receiving the events emitted by chain
+type EventSubscription interface {
+	Subscribe(ctx context.Context, filter types.EventFilter) (<-chan *types.ChainEvent, error)
+}
+
+// Subscribe returns a channel of the typed events passing the filter, in the order they are emitted. The events
+// are fetched block by block once the blocks are committed, the blocks are fetched again if the node is
+// unreachable, so no event is missed. To resume after a restart, a consumer sets FromHeight of the filter to the
+// height of the last block whose events it has all handled plus 1. The events are delivered at least once: if the
+// consumer resumes from a block it handled partly, the events of that block are delivered again.
+// The subscription stops if the events of a block can not be fetched even when retried, e.g. the block is pruned
+// by the node, then the last value of the channel carries the error in Err. The events which fail to be decoded
+// into typed events are skipped. The channel is closed when the subscription stops or ctx is canceled.
+func (c *client) Subscribe(ctx context.Context, filter types.EventFilter) (<-chan *types.ChainEvent, error) {
+	next := filter.FromHeight
+	if next <= 0 {
+		latestHeight, err := c.GetLatestBlockHeight(ctx)
+		if err != nil {
+			return nil, err
+		}
+		next = latestHeight + 1
+	}
+	bufferSize := filter.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = types.DefaultEventBufferSize
+	}
+
+	events := make(chan *types.ChainEvent, bufferSize)
+	go func() {
+		defer close(events)
+		for {
+			if err := c.WaitForBlockHeight(ctx, next); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Error().Msg(fmt.Sprintf("wait for block %d to subscribe events fail, err: %s", next, err))
+				if !sleepWithContext(ctx, subscribeRetryInterval) {
+					return
+				}
+				continue
+			}
+			blockEvents, err := c.getBlockEvents(ctx, next, filter)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if !isTransientRPCError(err) {
+					err = fmt.Errorf("fetch the events of block %d fail: %w", next, err)
+					select {
+					case events <- &types.ChainEvent{Height: next, Err: err}:
+					case <-ctx.Done():
+					}
+					return
+				}
+				log.Error().Msg(fmt.Sprintf("fetch the events of block %d fail, err: %s", next, err))
+				if !sleepWithContext(ctx, subscribeRetryInterval) {
+					return
+				}
+				continue
+			}
+			for _, event := range blockEvents {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			next++
+		}
+	}()
+	return events, nil
+}
+
+// isTransientRPCError reports whether the events of a block may be fetched if retried. The errors returned by the
+// node itself are permanent, e.g. the block is pruned, except the one that the block is not committed by the node
+// yet, which happens when the requests are balanced among nodes. The errors of decoding the block are permanent.
+func isTransientRPCError(err error) bool {
+	var decodeErr *blockDecodeError
+	if errors.As(err, &decodeErr) {
+		return false
+	}
+	var rpcErr *rpctypes.RPCError
+	if !errors.As(err, &rpcErr) {
+		return true
+	}
+	return strings.Contains(rpcErr.Data, "must be less than or equal to the current blockchain height")
+}
+
+// blockDecodeError is returned if the block results do not match the block
+type blockDecodeError struct {
+	msg string
+}
+
+func (e *blockDecodeError) Error() string {
+	return e.msg
+}
+
+// getBlockEvents returns the typed events of the block passing the filter
+func (c *client) getBlockEvents(ctx context.Context, height int64, filter types.EventFilter) ([]*types.ChainEvent, error) {
+	results, err := c.tmClient.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+	var txHashes []string
+	if len(results.TxsResults) > 0 {
+		block, err := c.tmClient.Block(ctx, &height)
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range block.Block.Txs {
+			txHashes = append(txHashes, fmt.Sprintf("%X", tx.Hash()))
+		}
+	}
+	return decodeBlockEvents(results, txHashes, filter)
+}
+
+// decodeBlockEvents decodes the typed events of the block results passing the filter, the events at the beginning
+// of the block come first, then the ones of the successful txs, then the ones at the end of the block
+func decodeBlockEvents(results *ctypes.ResultBlockResults, txHashes []string, filter types.EventFilter) ([]*types.ChainEvent, error) {
+	if len(txHashes) != len(results.TxsResults) {
+		return nil, &blockDecodeError{fmt.Sprintf("block %d has %d txs but %d tx results", results.Height, len(txHashes), len(results.TxsResults))}
+	}
+	chainEvents := make([]*types.ChainEvent, 0)
+	appendEvents := func(txHash string, events []abci.Event) {
+		for _, event := range events {
+			if !filter.Match(event.Type) {
+				continue
+			}
+			typedEvent, err := types.ParseTypedEvent(event)
+			if err != nil {
+				log.Error().Msg(fmt.Sprintf("skip the event %s of block %d, err: %s", event.Type, results.Height, err))
+				continue
+			}
+			chainEvents = append(chainEvents, &types.ChainEvent{Height: results.Height, TxHash: txHash, Event: typedEvent})
+		}
+	}
+
+	appendEvents("", results.BeginBlockEvents)
+	for i, txResult := range results.TxsResults {
+		if txResult.Code == 0 {
+			appendEvents(txHashes[i], txResult.Events)
+		}
+	}
+	appendEvents("", results.EndBlockEvents)
+	return chainEvents, nil
+}
+
+// sleepWithContext waits for the duration, it returns false if ctx is canceled before
+func sleepWithContext(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
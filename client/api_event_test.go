@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	paymentTypes "github.com/bnb-chain/greenfield/x/payment/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func toABCIEvent(t *testing.T, event proto.Message) abci.Event {
+	sdkEvent, err := sdk.TypedEventToEvent(event)
+	require.NoError(t, err)
+	return abci.Event(sdkEvent)
+}
+
+func TestDecodeBlockEvents(t *testing.T) {
+	results := &ctypes.ResultBlockResults{
+		Height: 5,
+		TxsResults: []*abci.ResponseDeliverTx{
+			{Events: []abci.Event{
+				{Type: "message", Attributes: []abci.EventAttribute{{Key: []byte("sender"), Value: []byte("0x01")}}},
+				toABCIEvent(t, &storageTypes.EventCreateBucket{BucketName: "bucket", BucketId: sdk.NewUint(1)}),
+			}},
+			// the events of the failed tx are reverted
+			{Code: 5, Events: []abci.Event{toABCIEvent(t, &storageTypes.EventDeleteBucket{BucketName: "other"})}},
+			{Events: []abci.Event{toABCIEvent(t, &storageTypes.EventSealObject{BucketName: "bucket", ObjectName: "object"})}},
+		},
+		EndBlockEvents: []abci.Event{toABCIEvent(t, &paymentTypes.EventStreamRecordUpdate{Account: "0x02"})},
+	}
+	txHashes := []string{"HASH1", "HASH2", "HASH3"}
+
+	events, err := decodeBlockEvents(results, txHashes, types.EventFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, "HASH1", events[0].TxHash)
+	assert.Equal(t, int64(5), events[0].Height)
+	createBucket, ok := events[0].Event.(*storageTypes.EventCreateBucket)
+	require.True(t, ok)
+	assert.Equal(t, "bucket", createBucket.BucketName)
+	assert.Equal(t, "HASH3", events[1].TxHash)
+	_, ok = events[2].Event.(*paymentTypes.EventStreamRecordUpdate)
+	assert.True(t, ok)
+	assert.Empty(t, events[2].TxHash)
+
+	filter := types.EventFilter{EventTypes: []string{proto.MessageName(&storageTypes.EventSealObject{})}}
+	events, err = decodeBlockEvents(results, txHashes, filter)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	_, ok = events[0].Event.(*storageTypes.EventSealObject)
+	assert.True(t, ok)
+
+	_, err = decodeBlockEvents(results, txHashes[:1], filter)
+	assert.Error(t, err)
+	assert.False(t, isTransientRPCError(err))
+}
+
+func TestIsTransientRPCError(t *testing.T) {
+	// the node is unreachable
+	assert.True(t, isTransientRPCError(errors.New("post failed: connection refused")))
+	// the block is not committed by the node the request is balanced to yet
+	assert.True(t, isTransientRPCError(fmt.Errorf("fetch block results: %w", &rpctypes.RPCError{
+		Code: -32603, Message: "Internal error", Data: "height 12 must be less than or equal to the current blockchain height 11",
+	})))
+	// the block is pruned
+	assert.False(t, isTransientRPCError(&rpctypes.RPCError{
+		Code: -32603, Message: "Internal error", Data: "height 1 is not available, lowest height is 100",
+	}))
+}
+
+func TestSubscribeUnreachable(t *testing.T) {
+	// the subscription fails instead of replaying the chain from the first block
+	c, err := New("greenfield_9000-121", "http://127.0.0.1:1", Option{})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = c.GetLatestBlockHeight(ctx)
+	assert.Error(t, err)
+	_, err = c.Subscribe(ctx, types.EventFilter{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+// FeeGrant interface defines the functions of paying the tx fees of other accounts.
+// The grantee sets the granter as the TxOption.FeeGranter of its txs, or as Option.FeeGranter of the client.
+type FeeGrant interface {
+	GrantAllowance(ctx context.Context, granteeAddr string, allowance feegrant.FeeAllowanceI, txOption gnfdSdkTypes.TxOption) (string, error)
+	RevokeAllowance(ctx context.Context, granteeAddr string, txOption gnfdSdkTypes.TxOption) (string, error)
+	QueryAllowance(ctx context.Context, granterAddr, granteeAddr string) (*feegrant.Grant, error)
+	QueryAllowances(ctx context.Context, granteeAddr string) ([]*feegrant.Grant, error)
+}
+
+// GrantAllowance grants the grantee to pay its tx fees from the account within the allowance, which is built
+// by utils.NewBasicAllowance or utils.NewPeriodicAllowance
+func (c *client) GrantAllowance(ctx context.Context, granteeAddr string, allowance feegrant.FeeAllowanceI, txOption gnfdSdkTypes.TxOption) (string, error) {
+	grantee, err := sdk.AccAddressFromHexUnsafe(granteeAddr)
+	if err != nil {
+		return "", err
+	}
+	msg, err := feegrant.NewMsgGrantAllowance(allowance, c.senderAddress(ctx), grantee)
+	if err != nil {
+		return "", err
+	}
+	return c.sendTxn(ctx, msg, &txOption)
+}
+
+// RevokeAllowance revokes the allowance granted to the grantee
+func (c *client) RevokeAllowance(ctx context.Context, granteeAddr string, txOption gnfdSdkTypes.TxOption) (string, error) {
+	grantee, err := sdk.AccAddressFromHexUnsafe(granteeAddr)
+	if err != nil {
+		return "", err
+	}
+	msg := feegrant.NewMsgRevokeAllowance(c.senderAddress(ctx), grantee)
+	return c.sendTxn(ctx, &msg, &txOption)
+}
+
+// QueryAllowance returns the allowance granted by the granter to the grantee
+func (c *client) QueryAllowance(ctx context.Context, granterAddr, granteeAddr string) (*feegrant.Grant, error) {
+	granter, err := sdk.AccAddressFromHexUnsafe(granterAddr)
+	if err != nil {
+		return nil, err
+	}
+	grantee, err := sdk.AccAddressFromHexUnsafe(granteeAddr)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.chainClient.FeegrantQueryClient.Allowance(ctx, &feegrant.QueryAllowanceRequest{
+		Granter: granter.String(),
+		Grantee: grantee.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Allowance, nil
+}
+
+// QueryAllowances returns all the allowances granted to the grantee
+func (c *client) QueryAllowances(ctx context.Context, granteeAddr string) ([]*feegrant.Grant, error) {
+	grantee, err := sdk.AccAddressFromHexUnsafe(granteeAddr)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		allowances []*feegrant.Grant
+		nextKey    []byte
+	)
+	for {
+		resp, err := c.chainClient.FeegrantQueryClient.Allowances(ctx, &feegrant.QueryAllowancesRequest{
+			Grantee:    grantee.String(),
+			Pagination: &query.PageRequest{Key: nextKey},
+		})
+		if err != nil {
+			return nil, err
+		}
+		allowances = append(allowances, resp.Allowances...)
+		if resp.Pagination == nil || len(resp.Pagination.NextKey) == 0 {
+			return allowances, nil
+		}
+		nextKey = resp.Pagination.NextKey
+	}
+}
+
+// withFeeGranter returns a copy of the tx option with the fee granter of the client if it has none
+func (c *client) withFeeGranter(txOpt *gnfdSdkTypes.TxOption) *gnfdSdkTypes.TxOption {
+	if c.feeGranter.Empty() || (txOpt != nil && !txOpt.FeeGranter.Empty()) {
+		return txOpt
+	}
+	withGranter := gnfdSdkTypes.TxOption{}
+	if txOpt != nil {
+		withGranter = *txOpt
+	}
+	withGranter.FeeGranter = c.feeGranter
+	return &withGranter
+}
@@ -49,13 +49,13 @@ type Group interface {
 
 // CreateGroup create a new group on greenfield chain, the group members can be initialized or not
 func (c *client) CreateGroup(ctx context.Context, groupName string, opt types.CreateGroupOptions) (string, error) {
-	createGroupMsg := storageTypes.NewMsgCreateGroup(c.MustGetDefaultAccount().GetAddress(), groupName, opt.InitGroupMember)
+	createGroupMsg := storageTypes.NewMsgCreateGroup(c.senderAddress(ctx), groupName, opt.InitGroupMember)
 	return c.sendTxn(ctx, createGroupMsg, opt.TxOpts)
 }
 
 // DeleteGroup send DeleteGroup txn to greenfield chain and return txn hash
 func (c *client) DeleteGroup(ctx context.Context, groupName string, opt types.DeleteGroupOption) (string, error) {
-	deleteGroupMsg := storageTypes.NewMsgDeleteGroup(c.MustGetDefaultAccount().GetAddress(), groupName)
+	deleteGroupMsg := storageTypes.NewMsgDeleteGroup(c.senderAddress(ctx), groupName)
 	return c.sendTxn(ctx, deleteGroupMsg, opt.TxOpts)
 }
 
@@ -93,7 +93,7 @@ func (c *client) UpdateGroupMember(ctx context.Context, groupName string, groupO
 		removeMembers = append(removeMembers, member)
 	}
 
-	updateGroupMsg := storageTypes.NewMsgUpdateGroupMember(c.MustGetDefaultAccount().GetAddress(), groupOwner, groupName, addMembers, removeMembers)
+	updateGroupMsg := storageTypes.NewMsgUpdateGroupMember(c.senderAddress(ctx), groupOwner, groupName, addMembers, removeMembers)
 
 	return c.sendTxn(ctx, updateGroupMsg, opts.TxOpts)
 }
@@ -104,7 +104,7 @@ func (c *client) LeaveGroup(ctx context.Context, groupName string, groupOwnerAdd
 	if err != nil {
 		return "", err
 	}
-	leaveGroupMsg := storageTypes.NewMsgLeaveGroup(c.MustGetDefaultAccount().GetAddress(), groupOwner, groupName)
+	leaveGroupMsg := storageTypes.NewMsgLeaveGroup(c.senderAddress(ctx), groupOwner, groupName)
 	return c.sendTxn(ctx, leaveGroupMsg, opt.TxOpts)
 }
 
@@ -139,7 +139,7 @@ func (c *client) HeadGroupMember(ctx context.Context, groupName string, groupOwn
 // PutGroupPolicy apply group policy to user specified by principalAddr, the sender need to be the owner of the group
 func (c *client) PutGroupPolicy(ctx context.Context, groupName string, principalAddr string,
 	statements []*permTypes.Statement, opt types.PutPolicyOption) (string, error) {
-	sender := c.MustGetDefaultAccount().GetAddress()
+	sender := c.senderAddress(ctx)
 
 	resource := gnfdTypes.NewGroupGRN(sender, groupName)
 
@@ -191,7 +191,7 @@ func (c *client) GetObjectPolicyOfGroup(ctx context.Context, bucketName, objectN
 
 // DeleteGroupPolicy delete group policy of the principal, the sender need to be the owner of the group
 func (c *client) DeleteGroupPolicy(ctx context.Context, groupName string, principalAddr string, opt types.DeletePolicyOption) (string, error) {
-	sender := c.MustGetDefaultAccount().GetAddress()
+	sender := c.senderAddress(ctx)
 	resource := gnfdTypes.NewGroupGRN(sender, groupName).String()
 
 	addr, err := sdk.AccAddressFromHexUnsafe(principalAddr)
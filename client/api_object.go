@@ -129,7 +129,7 @@ func (c *client) CreateObject(ctx context.Context, bucketName, objectName string
 		visibility = opts.Visibility
 	}
 
-	createObjectMsg := storageTypes.NewMsgCreateObject(c.MustGetDefaultAccount().GetAddress(), bucketName, objectName,
+	createObjectMsg := storageTypes.NewMsgCreateObject(c.senderAddress(ctx), bucketName, objectName,
 		uint64(size), visibility, expectCheckSums, contentType, redundancyType, math.MaxUint, nil, opts.SecondarySPAccs)
 	err = createObjectMsg.ValidateBasic()
 	if err != nil {
@@ -147,7 +147,7 @@ func (c *client) CreateObject(ctx context.Context, bucketName, objectName string
 		opts.TxOpts = &gnfdsdk.TxOption{Mode: &broadcastMode}
 	}
 
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{signedCreateObjectMsg}, opts.TxOpts)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{signedCreateObjectMsg}, opts.TxOpts)
 	if err != nil {
 		return "", err
 	}
@@ -164,7 +164,7 @@ func (c *client) DeleteObject(ctx context.Context, bucketName, objectName string
 		return "", err
 	}
 
-	delObjectMsg := storageTypes.NewMsgDeleteObject(c.MustGetDefaultAccount().GetAddress(), bucketName, objectName)
+	delObjectMsg := storageTypes.NewMsgDeleteObject(c.senderAddress(ctx), bucketName, objectName)
 	return c.sendTxn(ctx, delObjectMsg, opt.TxOpts)
 }
 
@@ -178,7 +178,7 @@ func (c *client) CancelCreateObject(ctx context.Context, bucketName, objectName
 		return "", err
 	}
 
-	cancelCreateMsg := storageTypes.NewMsgCancelCreateObject(c.MustGetDefaultAccount().GetAddress(), bucketName, objectName)
+	cancelCreateMsg := storageTypes.NewMsgCancelCreateObject(c.senderAddress(ctx), bucketName, objectName)
 	return c.sendTxn(ctx, cancelCreateMsg, opt.TxOpts)
 }
 
@@ -220,7 +220,7 @@ func (c *client) PutObject(ctx context.Context, bucketName, objectName string, o
 		}
 	}
 
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
 		return err
@@ -278,7 +278,7 @@ func (c *client) GetObject(ctx context.Context, bucketName, objectName string,
 		disableCloseBody: true,
 	}
 
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed,  err: %s", bucketName, err.Error()))
 		return nil, types.ObjectStat{}, err
@@ -398,7 +398,7 @@ func (c *client) PutObjectPolicy(ctx context.Context, bucketName, objectName str
 		return "", err
 	}
 
-	putPolicyMsg := storageTypes.NewMsgPutPolicy(c.MustGetDefaultAccount().GetAddress(), resource.String(),
+	putPolicyMsg := storageTypes.NewMsgPutPolicy(c.senderAddress(ctx), resource.String(),
 		principal, statements, opt.PolicyExpireTime)
 
 	return c.sendPutPolicyTxn(ctx, putPolicyMsg, opt.TxOpts)
@@ -414,7 +414,7 @@ func (c *client) DeleteObjectPolicy(ctx context.Context, bucketName, objectName
 
 	principal := permTypes.NewPrincipalWithAccount(addr)
 	resource := gnfdTypes.NewObjectGRN(bucketName, objectName)
-	return c.sendDelPolicyTxn(ctx, c.MustGetDefaultAccount().GetAddress(), resource.String(), principal, opt.TxOpts)
+	return c.sendDelPolicyTxn(ctx, c.senderAddress(ctx), resource.String(), principal, opt.TxOpts)
 }
 
 // IsObjectPermissionAllowed check if the permission of the object is allowed to the user
@@ -476,7 +476,7 @@ func (c *client) ListObjects(ctx context.Context, bucketName string, opts types.
 		disableCloseBody: true,
 	}
 
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
 		return types.ListObjectsResult{}, err
@@ -544,7 +544,7 @@ func (c *client) GetCreateObjectApproval(ctx context.Context, createObjectMsg *s
 	}
 
 	bucketName := createObjectMsg.BucketName
-	endpoint, err := c.getSPUrlByBucket(bucketName)
+	endpoint, err := c.getSPUrlByBucket(ctx, bucketName)
 	if err != nil {
 		log.Error().Msg(fmt.Sprintf("route endpoint by bucket: %s failed, err: %s", bucketName, err.Error()))
 		return nil, err
@@ -37,11 +37,11 @@ func (c *client) Deposit(ctx context.Context, toAddress string, amount math.Int,
 		return "", err
 	}
 	msgDeposit := &paymentTypes.MsgDeposit{
-		Creator: c.MustGetDefaultAccount().GetAddress().String(),
+		Creator: c.senderAddress(ctx).String(),
 		To:      accAddress.String(),
 		Amount:  amount,
 	}
-	tx, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgDeposit}, &txOption)
+	tx, err := c.broadcastTx(ctx, []sdk.Msg{msgDeposit}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -55,11 +55,11 @@ func (c *client) Withdraw(ctx context.Context, fromAddress string, amount math.I
 		return "", err
 	}
 	msgWithdraw := &paymentTypes.MsgWithdraw{
-		Creator: c.MustGetDefaultAccount().GetAddress().String(),
+		Creator: c.senderAddress(ctx).String(),
 		From:    accAddress.String(),
 		Amount:  amount,
 	}
-	tx, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgWithdraw}, &txOption)
+	tx, err := c.broadcastTx(ctx, []sdk.Msg{msgWithdraw}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -73,10 +73,10 @@ func (c *client) DisableRefund(ctx context.Context, paymentAddress string, txOpt
 		return "", err
 	}
 	msgDisableRefund := &paymentTypes.MsgDisableRefund{
-		Owner: c.MustGetDefaultAccount().GetAddress().String(),
+		Owner: c.senderAddress(ctx).String(),
 		Addr:  accAddress.String(),
 	}
-	tx, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgDisableRefund}, &txOption)
+	tx, err := c.broadcastTx(ctx, []sdk.Msg{msgDisableRefund}, &txOption)
 	if err != nil {
 		return "", err
 	}
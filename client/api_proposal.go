@@ -20,7 +20,7 @@ type Proposal interface {
 }
 
 func (c *client) SubmitProposal(ctx context.Context, msgs []sdk.Msg, depositAmount math.Int, opts types.SubmitProposalOptions) (uint64, string, error) {
-	msgSubmitProposal, err := govTypesV1.NewMsgSubmitProposal(msgs, sdk.NewCoins(sdk.NewCoin(gnfdSdkTypes.Denom, depositAmount)), c.defaultAccount.GetAddress().String(), opts.Metadata)
+	msgSubmitProposal, err := govTypesV1.NewMsgSubmitProposal(msgs, sdk.NewCoins(sdk.NewCoin(gnfdSdkTypes.Denom, depositAmount)), c.senderAddress(ctx).String(), opts.Metadata)
 	if err != nil {
 		return 0, "", err
 	}
@@ -28,10 +28,17 @@ func (c *client) SubmitProposal(ctx context.Context, msgs []sdk.Msg, depositAmou
 	if err != nil {
 		return 0, "", err
 	}
-	txResp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgSubmitProposal}, &opts.TxOption)
+	txResp, err := c.broadcastTx(ctx, []sdk.Msg{msgSubmitProposal}, &opts.TxOption)
 	if err != nil {
 		return 0, "", err
 	}
+	// the proposal id is unknown until the generated or dry-run tx is broadcast
+	if _, ok := generateOnlyFromContext(ctx); ok {
+		return 0, "", nil
+	}
+	if _, ok := dryRunFromContext(ctx); ok {
+		return 0, "", nil
+	}
 	waitCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	waitForTx, err := c.WaitForTx(waitCtx, txResp.TxResponse.TxHash)
@@ -57,8 +64,8 @@ func (c *client) SubmitProposal(ctx context.Context, msgs []sdk.Msg, depositAmou
 }
 
 func (c *client) VoteProposal(ctx context.Context, proposalID uint64, voteOption govTypesV1.VoteOption, opts types.VoteProposalOptions) (string, error) {
-	msgVote := govTypesV1.NewMsgVote(c.MustGetDefaultAccount().GetAddress(), proposalID, voteOption, opts.Metadata)
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgVote}, &opts.TxOption)
+	msgVote := govTypesV1.NewMsgVote(c.senderAddress(ctx), proposalID, voteOption, opts.Metadata)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{msgVote}, &opts.TxOption)
 	if err != nil {
 		return "", err
 	}
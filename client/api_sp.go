@@ -33,6 +33,15 @@ type SP interface {
 	CreateStorageProvider(ctx context.Context, fundingAddr, sealAddr, approvalAddr, gcAddr string, endpoint string, depositAmount math.Int, description spTypes.Description, opts types.CreateStorageProviderOptions) (uint64, string, error)
 	// UpdateSpStoragePrice updates the read price, storage price and free read quota for a particular storage provider
 	UpdateSpStoragePrice(ctx context.Context, spAddr string, readPrice, storePrice sdk.Dec, freeReadQuota uint64, TxOption gnfdSdkTypes.TxOption) (string, error)
+	// SelectStorageProviders returns the healthy in-service SPs from the best one by the scores of the option
+	SelectStorageProviders(ctx context.Context, opt types.SPSelectOption) ([]*types.SPCandidate, error)
+	// RefreshStorageProviders queries the SP endpoints from chain right away instead of waiting for the cached ones to expire
+	RefreshStorageProviders(ctx context.Context) error
+}
+
+// RefreshStorageProviders queries the SP endpoints from chain and replaces the cached ones
+func (c *client) RefreshStorageProviders(ctx context.Context) error {
+	return c.sps.refresh(ctx)
 }
 
 func (c *client) GetStoragePrice(ctx context.Context, spAddr string) (*spTypes.SpStoragePrice, error) {
@@ -95,8 +104,7 @@ func (c *client) GetStorageProviderInfo(ctx context.Context, SPAddr sdk.AccAddre
 	return gnfdRep.StorageProvider, nil
 }
 
-func (c *client) getSPUrlList() (map[string]*url.URL, error) {
-	ctx := context.Background()
+func (c *client) getSPUrlList(ctx context.Context) (map[string]*url.URL, error) {
 	spInfo := make(map[string]*url.URL, 0)
 	request := &spTypes.QueryStorageProvidersRequest{}
 	gnfdRep, err := c.chainClient.StorageProviders(ctx, request)
@@ -129,7 +137,7 @@ func (c *client) getSPUrlList() (map[string]*url.URL, error) {
 
 // CreateStorageProvider will submit a CreateStorageProvider proposal and return proposalID, TxHash and err if it has.
 func (c *client) CreateStorageProvider(ctx context.Context, fundingAddr, sealAddr, approvalAddr, gcAddr string, endpoint string, depositAmount math.Int, description spTypes.Description, opts types.CreateStorageProviderOptions) (uint64, string, error) {
-	defaultAccount := c.MustGetDefaultAccount()
+	operatorAddr := c.senderAddress(ctx)
 	govModuleAddress, err := c.GetModuleAccountByName(ctx, govTypes.ModuleName)
 	if err != nil {
 		return 0, "", err
@@ -162,7 +170,7 @@ func (c *client) CreateStorageProvider(ctx context.Context, fundingAddr, sealAdd
 	}
 	msgCreateStorageProvider, err := spTypes.NewMsgCreateStorageProvider(
 		govModuleAddress.GetAddress(),
-		defaultAccount.GetAddress(),
+		operatorAddr,
 		fundingAcc, sealAcc, approvalAcc, gcAcc, description,
 		endpoint,
 		sdk.NewCoin(gnfdSdkTypes.Denom, depositAmount),
@@ -182,7 +190,7 @@ func (c *client) CreateStorageProvider(ctx context.Context, fundingAddr, sealAdd
 }
 
 func (c *client) GrantDepositForStorageProvider(ctx context.Context, spAddr string, depositAmount math.Int, opts types.GrantDepositForStorageProviderOptions) (string, error) {
-	granter := c.MustGetDefaultAccount()
+	granter := c.senderAddress(ctx)
 	govModuleAddress, err := c.GetModuleAccountByName(ctx, govTypes.ModuleName)
 	if err != nil {
 		return "", err
@@ -198,11 +206,11 @@ func (c *client) GrantDepositForStorageProvider(ctx context.Context, spAddr stri
 		expiration := time.Now().Add(24 * time.Hour)
 		opts.Expiration = &expiration
 	}
-	msgGrant, err := authz.NewMsgGrant(granter.GetAddress(), govModuleAddress.GetAddress(), authorization, opts.Expiration)
+	msgGrant, err := authz.NewMsgGrant(granter, govModuleAddress.GetAddress(), authorization, opts.Expiration)
 	if err != nil {
 		return "", err
 	}
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgGrant}, &opts.TxOption)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{msgGrant}, &opts.TxOption)
 	if err != nil {
 		return "", err
 	}
@@ -220,7 +228,7 @@ func (c *client) UpdateSpStoragePrice(ctx context.Context, spAddr string, readPr
 		StorePrice:    storePrice,
 		FreeReadQuota: freeReadQuota,
 	}
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdk.Msg{msgUpdateStoragePrice}, &TxOption)
+	resp, err := c.broadcastTx(ctx, []sdk.Msg{msgUpdateStoragePrice}, &TxOption)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// Tx interface defines the functions of building the txs for offline signing.
+// The signed tx bytes are submitted by BroadcastRawTx.
+type Tx interface {
+	BuildUnsignedTx(ctx context.Context, msgs []sdk.Msg, txOpt gnfdSdkTypes.TxOption) (*types.UnsignedTx, error)
+}
+
+type generateOnlyContextKey struct{}
+
+// WithGenerateOnly returns a copy of ctx with which the write APIs build the unsigned tx into unsignedTx instead
+// of signing and broadcasting it, the APIs return an empty tx hash. The tx is built for the account of the
+// context, which can be a watch-only account created by types.NewAccountFromPubKey. Note that the SP approval
+// requests of the create flows are still signed by the account, so such flows need an account able to sign.
+func WithGenerateOnly(ctx context.Context, unsignedTx *types.UnsignedTx) context.Context {
+	return context.WithValue(ctx, generateOnlyContextKey{}, unsignedTx)
+}
+
+func generateOnlyFromContext(ctx context.Context) (*types.UnsignedTx, bool) {
+	unsignedTx, ok := ctx.Value(generateOnlyContextKey{}).(*types.UnsignedTx)
+	return unsignedTx, ok && unsignedTx != nil
+}
+
+type dryRunContextKey struct{}
+
+// WithDryRun returns a copy of ctx with which the write APIs build and simulate the tx instead of broadcasting it,
+// the msgs, the estimated gas and fee and the simulated events are filled into result, and the APIs return an
+// empty tx hash. The error of the simulation, such as the permission denied by the chain, is returned by the APIs.
+func WithDryRun(ctx context.Context, result *types.DryRunResult) context.Context {
+	return context.WithValue(ctx, dryRunContextKey{}, result)
+}
+
+func dryRunFromContext(ctx context.Context) (*types.DryRunResult, bool) {
+	result, ok := ctx.Value(dryRunContextKey{}).(*types.DryRunResult)
+	return result, ok && result != nil
+}
+
+// BuildUnsignedTx builds the unsigned tx of the msgs for the account of ctx, the account number and sequence
+// are queried from chain and the gas is simulated unless txOpt.NoSimulate is set. txOpt.Nonce overrides the
+// sequence, so that several txs can be built ahead and signed offline in order.
+func (c *client) BuildUnsignedTx(ctx context.Context, msgs []sdk.Msg, txOpt gnfdSdkTypes.TxOption) (*types.UnsignedTx, error) {
+	msgs, err := c.wrapExecAs(ctx, msgs)
+	if err != nil {
+		return nil, err
+	}
+	return c.buildUnsignedTx(ctx, msgs, c.withFeeGranter(&txOpt))
+}
+
+func (c *client) buildUnsignedTx(ctx context.Context, msgs []sdk.Msg, txOpt *gnfdSdkTypes.TxOption) (*types.UnsignedTx, error) {
+	account, err := c.getAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if txOpt == nil {
+		txOpt = &gnfdSdkTypes.TxOption{}
+	}
+	chainID, err := c.chainClient.GetChainId()
+	if err != nil {
+		return nil, err
+	}
+	accountInfo, err := c.GetAccount(ctx, account.GetAddress().String())
+	if err != nil {
+		return nil, err
+	}
+	sequence := accountInfo.GetSequence()
+	if txOpt.Nonce != 0 {
+		sequence = txOpt.Nonce
+	}
+
+	txConfig := types.NewTxConfig()
+	txBuilder := txConfig.NewTxBuilder()
+	for _, msg := range msgs {
+		if err = msg.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+	if err = txBuilder.SetMsgs(msgs...); err != nil {
+		return nil, err
+	}
+	txBuilder.SetMemo(txOpt.Memo)
+	if !txOpt.FeePayer.Empty() {
+		txBuilder.SetFeePayer(txOpt.FeePayer)
+	}
+	if !txOpt.FeeGranter.Empty() {
+		txBuilder.SetFeeGranter(txOpt.FeeGranter)
+	}
+	if txOpt.Tip != nil {
+		txBuilder.SetTip(txOpt.Tip)
+	}
+	// the signer info without signature is needed for simulating and signing
+	var sigData signing.SignatureData = &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_EIP_712}
+	if multisigPubKey, ok := account.PubKey().(multisig.PubKey); ok {
+		sigData = types.NewMultisigPlaceholder(multisigPubKey)
+	}
+	err = txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   account.PubKey(),
+		Data:     sigData,
+		Sequence: sequence,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if txOpt.NoSimulate {
+		if txOpt.GasLimit == 0 || txOpt.FeeAmount.IsZero() {
+			return nil, gnfdSdkTypes.GasInfoNotProvidedError
+		}
+		txBuilder.SetGasLimit(txOpt.GasLimit)
+		txBuilder.SetFeeAmount(txOpt.FeeAmount)
+	} else {
+		txBytes, err := txConfig.TxEncoder()(txBuilder.GetTx())
+		if err != nil {
+			return nil, err
+		}
+		simulateRes, err := c.SimulateRawTx(ctx, txBytes)
+		if err != nil {
+			return nil, err
+		}
+		estimate, err := c.gasPolicy(ctx).Estimate(simulateRes.GasInfo)
+		if err != nil {
+			return nil, err
+		}
+		txBuilder.SetGasLimit(estimate.GasLimit)
+		txBuilder.SetFeeAmount(estimate.Fee)
+	}
+
+	txJSON, err := txConfig.TxJSONEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	return &types.UnsignedTx{
+		ChainID:       chainID,
+		AccountNumber: accountInfo.GetAccountNumber(),
+		Sequence:      sequence,
+		Tx:            txJSON,
+	}, nil
+}
+
+// generateTx builds the unsigned tx into the generate-only target of ctx, the response has an empty tx hash
+func (c *client) generateTx(ctx context.Context, unsignedTx *types.UnsignedTx, msgs []sdk.Msg, txOpt *gnfdSdkTypes.TxOption) (*tx.BroadcastTxResponse, error) {
+	if len(unsignedTx.Tx) != 0 {
+		return nil, errors.New("the generate-only context has been used to build another tx")
+	}
+	built, err := c.buildUnsignedTx(ctx, msgs, txOpt)
+	if err != nil {
+		return nil, err
+	}
+	*unsignedTx = *built
+	return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{}}, nil
+}
+
+// dryRunTx simulates the tx into the dry-run result of ctx, the response has an empty tx hash
+func (c *client) dryRunTx(ctx context.Context, result *types.DryRunResult, msgs []sdk.Msg, txOpt *gnfdSdkTypes.TxOption) (*tx.BroadcastTxResponse, error) {
+	if len(result.Msgs) != 0 {
+		return nil, errors.New("the dry-run context has been used to simulate another tx")
+	}
+	cc, err := c.getChainClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	simulateRes, err := cc.SimulateTx(ctx, msgs, txOpt)
+	if err != nil {
+		return nil, err
+	}
+	estimate, err := c.gasPolicy(ctx).Estimate(simulateRes.GasInfo)
+	if err != nil {
+		return nil, err
+	}
+	result.Msgs = msgs
+	result.GasEstimate = estimate
+	if simulateRes.Result != nil {
+		result.Events = simulateRes.Result.Events
+	}
+	return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{}}, nil
+}
@@ -96,8 +96,8 @@ func (c *client) EditValidator(ctx context.Context, description stakingtypes.Des
 	if err != nil {
 		return "", err
 	}
-	msg := stakingtypes.NewMsgEditValidator(c.MustGetDefaultAccount().GetAddress(), description, newRate, newMinSelfDelegation, relayer, challenger, newBlsKey)
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
+	msg := stakingtypes.NewMsgEditValidator(c.senderAddress(ctx), description, newRate, newMinSelfDelegation, relayer, challenger, newBlsKey)
+	resp, err := c.broadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -110,8 +110,8 @@ func (c *client) DelegateValidator(ctx context.Context, validatorAddr string, am
 	if err != nil {
 		return "", err
 	}
-	msg := stakingtypes.NewMsgDelegate(c.MustGetDefaultAccount().GetAddress(), validator, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
+	msg := stakingtypes.NewMsgDelegate(c.senderAddress(ctx), validator, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
+	resp, err := c.broadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -128,8 +128,8 @@ func (c *client) BeginRedelegate(ctx context.Context, validatorSrcAddr, validato
 	if err != nil {
 		return "", err
 	}
-	msg := stakingtypes.NewMsgBeginRedelegate(c.MustGetDefaultAccount().GetAddress(), validatorSrc, validatorDest, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
+	msg := stakingtypes.NewMsgBeginRedelegate(c.senderAddress(ctx), validatorSrc, validatorDest, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
+	resp, err := c.broadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -142,8 +142,8 @@ func (c *client) Undelegate(ctx context.Context, validatorAddr string, amount ma
 	if err != nil {
 		return "", err
 	}
-	msg := stakingtypes.NewMsgUndelegate(c.MustGetDefaultAccount().GetAddress(), validator, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
+	msg := stakingtypes.NewMsgUndelegate(c.senderAddress(ctx), validator, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
+	resp, err := c.broadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -156,8 +156,8 @@ func (c *client) CancelUnbondingDelegation(ctx context.Context, validatorAddr st
 	if err != nil {
 		return "", err
 	}
-	msg := stakingtypes.NewMsgCancelUnbondingDelegation(c.MustGetDefaultAccount().GetAddress(), validator, creationHeight, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
+	msg := stakingtypes.NewMsgCancelUnbondingDelegation(c.senderAddress(ctx), validator, creationHeight, sdktypes.NewCoin(gnfdsdktypes.Denom, amount))
+	resp, err := c.broadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -171,21 +171,21 @@ func (c *client) GrantDelegationForValidator(ctx context.Context, delegationAmou
 		return "", err
 	}
 	delegationCoin := sdktypes.NewCoin(gnfdsdktypes.Denom, delegationAmount)
-	authorization, err := stakingtypes.NewStakeAuthorization([]sdktypes.AccAddress{c.MustGetDefaultAccount().GetAddress()},
+	authorization, err := stakingtypes.NewStakeAuthorization([]sdktypes.AccAddress{c.senderAddress(ctx)},
 		nil, stakingtypes.AuthorizationType_AUTHORIZATION_TYPE_DELEGATE,
 		&delegationCoin)
 	if err != nil {
 		return "", err
 	}
 
-	msgGrant, err := authz.NewMsgGrant(c.MustGetDefaultAccount().GetAddress(),
+	msgGrant, err := authz.NewMsgGrant(c.senderAddress(ctx),
 		govModule.GetAddress(),
 		authorization, nil)
 	if err != nil {
 		return "", err
 	}
 
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdktypes.Msg{msgGrant}, &txOption)
+	resp, err := c.broadcastTx(ctx, []sdktypes.Msg{msgGrant}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -194,8 +194,8 @@ func (c *client) GrantDelegationForValidator(ctx context.Context, delegationAmou
 
 // UnJailValidator unjails the validator
 func (c *client) UnJailValidator(ctx context.Context, txOption gnfdsdktypes.TxOption) (string, error) {
-	msg := slashingtypes.NewMsgUnjail(c.MustGetDefaultAccount().GetAddress())
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
+	msg := slashingtypes.NewMsgUnjail(c.senderAddress(ctx))
+	resp, err := c.broadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -208,8 +208,8 @@ func (c *client) ImpeachValidator(ctx context.Context, validatorAddr string, txO
 	if err != nil {
 		return "", err
 	}
-	msg := slashingtypes.NewMsgImpeach(validator, c.MustGetDefaultAccount().GetAddress())
-	resp, err := c.chainClient.BroadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
+	msg := slashingtypes.NewMsgImpeach(validator, c.senderAddress(ctx))
+	resp, err := c.broadcastTx(ctx, []sdktypes.Msg{msg}, &txOption)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	chttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	// wsPollInterval is the interval of querying the tx or the height in case an event of the websocket is missed
+	wsPollInterval = 5 * time.Second
+	// txIndexRetryInterval is the interval of querying the tx notified by the websocket until it is indexed
+	txIndexRetryInterval = 100 * time.Millisecond
+	// wsReconnectInterval is the wait before connecting the websocket again after it fails
+	wsReconnectInterval = 30 * time.Second
+	// wsEventBufferSize is the number of the events buffered for each subscription
+	wsEventBufferSize = 1000
+)
+
+var (
+	txEventQuery          = tmtypes.EventQueryTx.String()
+	blockHeaderEventQuery = tmtypes.EventQueryNewBlockHeader.String()
+)
+
+// eventWaiter notifies the waiters of the txs and the block heights by the events subscribed over the websocket
+// of the tendermint RPC. All the txs are subscribed by one query instead of a query per tx hash, since the node
+// limits the number of the subscriptions of a client. The websocket is kept until close is called.
+type eventWaiter struct {
+	endpoint string
+
+	mu sync.Mutex
+	// rpc is the connected websocket client, it is nil until the first wait
+	rpc *chttp.HTTP
+	// retryAt is the time before which the failed websocket is not connected again
+	retryAt time.Time
+	// closed is set once the waiter is closed, after which the websocket is not connected again
+	closed        bool
+	txWaiters     map[string][]chan struct{}
+	heightWaiters map[chan struct{}]int64
+}
+
+func newEventWaiter(endpoint string) *eventWaiter {
+	return &eventWaiter{
+		endpoint:      endpoint,
+		txWaiters:     make(map[string][]chan struct{}),
+		heightWaiters: make(map[chan struct{}]int64),
+	}
+}
+
+// connect subscribes the tx and the block header events if the websocket is not connected yet, the caller
+// must hold the lock
+func (w *eventWaiter) connect() error {
+	if w.rpc != nil {
+		return nil
+	}
+	if w.closed {
+		return errors.New("the websocket is closed")
+	}
+	if time.Now().Before(w.retryAt) {
+		return errors.New("the websocket is unavailable")
+	}
+
+	err := func() error {
+		rpc, err := chttp.New(w.endpoint, "/websocket")
+		if err != nil {
+			return err
+		}
+		if err = rpc.Start(); err != nil {
+			return err
+		}
+		txEvents, err := rpc.Subscribe(context.Background(), "", txEventQuery, wsEventBufferSize)
+		if err == nil {
+			var headerEvents <-chan ctypes.ResultEvent
+			headerEvents, err = rpc.Subscribe(context.Background(), "", blockHeaderEventQuery, wsEventBufferSize)
+			if err == nil {
+				w.rpc = rpc
+				go w.dispatch(rpc, txEvents, headerEvents)
+				return nil
+			}
+		}
+		_ = rpc.Stop()
+		return err
+	}()
+	if err != nil {
+		w.retryAt = time.Now().Add(wsReconnectInterval)
+		return fmt.Errorf("failed to subscribe the events of %s: %w", w.endpoint, err)
+	}
+	return nil
+}
+
+// dispatch notifies the waiters of the events until the websocket client is stopped
+func (w *eventWaiter) dispatch(rpc *chttp.HTTP, txEvents, headerEvents <-chan ctypes.ResultEvent) {
+	for {
+		select {
+		case event := <-txEvents:
+			if data, ok := event.Data.(tmtypes.EventDataTx); ok {
+				w.notifyTx(fmt.Sprintf("%X", tmtypes.Tx(data.Tx).Hash()))
+			}
+		case event := <-headerEvents:
+			if data, ok := event.Data.(tmtypes.EventDataNewBlockHeader); ok {
+				w.notifyHeight(data.Header.Height)
+			}
+		case <-rpc.Quit():
+			w.mu.Lock()
+			w.rpc = nil
+			w.mu.Unlock()
+			return
+		}
+	}
+}
+
+// close stops the websocket, the waits in progress fall back to polling once their channels are not notified
+func (w *eventWaiter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	if w.rpc == nil {
+		return nil
+	}
+	rpc := w.rpc
+	w.rpc = nil
+	return rpc.Stop()
+}
+
+// waitTx returns a channel closed when the tx is included in a block, the returned func stops the wait
+func (w *eventWaiter) waitTx(hash string) (<-chan struct{}, func(), error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.connect(); err != nil {
+		return nil, nil, err
+	}
+	hash = strings.ToUpper(hash)
+	notified := make(chan struct{})
+	w.txWaiters[hash] = append(w.txWaiters[hash], notified)
+	return notified, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		waiters := w.txWaiters[hash]
+		for i, waiter := range waiters {
+			if waiter == notified {
+				waiters = append(waiters[:i], waiters[i+1:]...)
+				break
+			}
+		}
+		if len(waiters) == 0 {
+			delete(w.txWaiters, hash)
+		} else {
+			w.txWaiters[hash] = waiters
+		}
+	}, nil
+}
+
+// waitHeight returns a channel closed when the block of the height is committed, the returned func stops the wait
+func (w *eventWaiter) waitHeight(height int64) (<-chan struct{}, func(), error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.connect(); err != nil {
+		return nil, nil, err
+	}
+	notified := make(chan struct{})
+	w.heightWaiters[notified] = height
+	return notified, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.heightWaiters, notified)
+	}, nil
+}
+
+func (w *eventWaiter) notifyTx(hash string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, waiter := range w.txWaiters[hash] {
+		close(waiter)
+	}
+	delete(w.txWaiters, hash)
+}
+
+func (w *eventWaiter) notifyHeight(height int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for waiter, h := range w.heightWaiters {
+		if h <= height {
+			close(waiter)
+			delete(w.heightWaiters, waiter)
+		}
+	}
+}
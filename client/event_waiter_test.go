@@ -0,0 +1,68 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	chttp "github.com/tendermint/tendermint/rpc/client/http"
+)
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestEventWaiter(t *testing.T) {
+	w := newEventWaiter("")
+	// the websocket is taken as connected
+	w.rpc = &chttp.HTTP{}
+
+	txNotified, _, err := w.waitTx("abcd")
+	require.NoError(t, err)
+	otherTxNotified, cancelOtherTx, err := w.waitTx("ABCD")
+	require.NoError(t, err)
+	cancelOtherTx()
+	w.notifyTx("FFFF")
+	assert.False(t, isClosed(txNotified))
+	w.notifyTx("ABCD")
+	assert.True(t, isClosed(txNotified))
+	assert.False(t, isClosed(otherTxNotified))
+	assert.Empty(t, w.txWaiters)
+
+	heightNotified, _, err := w.waitHeight(10)
+	require.NoError(t, err)
+	laterHeightNotified, cancelLaterHeight, err := w.waitHeight(12)
+	require.NoError(t, err)
+	w.notifyHeight(9)
+	assert.False(t, isClosed(heightNotified))
+	w.notifyHeight(11)
+	assert.True(t, isClosed(heightNotified))
+	assert.False(t, isClosed(laterHeightNotified))
+	cancelLaterHeight()
+	assert.Empty(t, w.heightWaiters)
+}
+
+func TestEventWaiterUnavailable(t *testing.T) {
+	w := newEventWaiter("http://127.0.0.1:1")
+	_, _, err := w.waitTx("ABCD")
+	assert.Error(t, err)
+	// the failed websocket is not connected again until the retry time
+	assert.True(t, w.retryAt.After(time.Now()))
+	_, _, err = w.waitHeight(10)
+	assert.ErrorContains(t, err, "unavailable")
+}
+
+func TestEventWaiterClose(t *testing.T) {
+	w := newEventWaiter("http://127.0.0.1:1")
+	require.NoError(t, w.close())
+	// the closed websocket is never connected again
+	_, _, err := w.waitTx("ABCD")
+	assert.ErrorContains(t, err, "closed")
+	assert.True(t, w.retryAt.IsZero())
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+
+	sdkclient "github.com/bnb-chain/greenfield/sdk/client"
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+type gasPolicyContextKey struct{}
+
+// WithGasPolicy returns a copy of ctx with which the txs are sent with the gas policy instead of the one of the client
+func WithGasPolicy(ctx context.Context, policy *types.GasPolicy) context.Context {
+	return context.WithValue(ctx, gasPolicyContextKey{}, policy)
+}
+
+// gasPolicy returns the gas policy of ctx, or the one of the client if ctx has none
+func (c *client) gasPolicy(ctx context.Context) *types.GasPolicy {
+	if policy, ok := ctx.Value(gasPolicyContextKey{}).(*types.GasPolicy); ok && policy != nil {
+		return policy
+	}
+	return c.defaultGasPolicy
+}
+
+// withEstimatedGas returns a copy of the tx option with the gas limit and the fee estimated by the gas policy of
+// ctx, the tx option is returned as it is if it disables the simulation
+func (c *client) withEstimatedGas(ctx context.Context, cc *sdkclient.GreenfieldClient, msgs []sdk.Msg,
+	txOpt *gnfdSdkTypes.TxOption) (*gnfdSdkTypes.TxOption, error) {
+	if txOpt != nil && txOpt.NoSimulate {
+		return txOpt, nil
+	}
+	simulateRes, err := cc.SimulateTx(ctx, msgs, txOpt)
+	if err != nil {
+		return nil, err
+	}
+	estimate, err := c.gasPolicy(ctx).Estimate(simulateRes.GasInfo)
+	if err != nil {
+		return nil, err
+	}
+	withGas := gnfdSdkTypes.TxOption{}
+	if txOpt != nil {
+		withGas = *txOpt
+	}
+	withGas.NoSimulate = true
+	withGas.GasLimit = estimate.GasLimit
+	withGas.FeeAmount = estimate.Fee
+	return &withGas, nil
+}
@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+const (
+	// maxSequenceRetries is the number of retries of a tx rejected for the account sequence mismatch
+	maxSequenceRetries = 5
+	// sequenceRetryBackoff is the wait before retrying a tx whose previous txs are not in the mempool yet
+	sequenceRetryBackoff = 200 * time.Millisecond
+)
+
+var sequenceMismatchRegexp = regexp.MustCompile(`account sequence mismatch, expected (\d+), got (\d+)`)
+
+// nonceManager hands out the sequences of the accounts locally, so that the txs of one account can be
+// broadcast concurrently without querying the sequence of the account for each of them
+type nonceManager struct {
+	mu       sync.Mutex
+	accounts map[string]*accountNonce
+}
+
+// accountNonce is the sequence state of an account
+type accountNonce struct {
+	mu     sync.Mutex
+	synced bool
+	// next is the sequence handed out next if there is no released one
+	next uint64
+	// released are the sequences handed out but not consumed by the chain, they are handed out again first
+	released []uint64
+	// pending are the sequences of the txs being broadcast
+	pending map[uint64]struct{}
+}
+
+func newNonceManager() *nonceManager {
+	return &nonceManager{accounts: make(map[string]*accountNonce)}
+}
+
+func (m *nonceManager) account(addr sdk.AccAddress) *accountNonce {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	an, ok := m.accounts[addr.String()]
+	if !ok {
+		an = &accountNonce{pending: make(map[uint64]struct{})}
+		m.accounts[addr.String()] = an
+	}
+	return an
+}
+
+// acquire hands out a sequence of the account, the sequence is fetched from chain the first time
+func (m *nonceManager) acquire(addr sdk.AccAddress, fetch func() (uint64, error)) (uint64, error) {
+	an := m.account(addr)
+	an.mu.Lock()
+	defer an.mu.Unlock()
+	if !an.synced {
+		sequence, err := fetch()
+		if err != nil {
+			return 0, err
+		}
+		an.next = sequence
+		an.released = nil
+		an.synced = true
+	}
+
+	var sequence uint64
+	if len(an.released) > 0 {
+		sequence = an.released[0]
+		an.released = an.released[1:]
+	} else {
+		sequence = an.next
+		an.next++
+	}
+	an.pending[sequence] = struct{}{}
+	return sequence, nil
+}
+
+// commit marks the sequence consumed by the chain
+func (m *nonceManager) commit(addr sdk.AccAddress, sequence uint64) {
+	an := m.account(addr)
+	an.mu.Lock()
+	defer an.mu.Unlock()
+	delete(an.pending, sequence)
+}
+
+// release gives back the sequence which is not consumed by the chain
+func (m *nonceManager) release(addr sdk.AccAddress, sequence uint64) {
+	an := m.account(addr)
+	an.mu.Lock()
+	defer an.mu.Unlock()
+	if _, ok := an.pending[sequence]; !ok {
+		return
+	}
+	delete(an.pending, sequence)
+	if sequence >= an.next {
+		return
+	}
+	an.released = append(an.released, sequence)
+	sort.Slice(an.released, func(i, j int) bool { return an.released[i] < an.released[j] })
+	// the released sequences at the end are simply not handed out yet
+	for len(an.released) > 0 && an.released[len(an.released)-1] == an.next-1 {
+		an.released = an.released[:len(an.released)-1]
+		an.next--
+	}
+}
+
+// resync moves the account to the sequence expected by the chain, the sequences below it are consumed
+func (m *nonceManager) resync(addr sdk.AccAddress, sequence, expected uint64) {
+	an := m.account(addr)
+	an.mu.Lock()
+	defer an.mu.Unlock()
+	delete(an.pending, sequence)
+	if expected > an.next {
+		an.next = expected
+	}
+	released := an.released[:0]
+	for _, s := range an.released {
+		if s >= expected {
+			released = append(released, s)
+		}
+	}
+	an.released = released
+}
+
+// broadcastTxWithNonce broadcasts the tx with the sequence handed out by the nonce manager, and retries it
+// with another sequence if the chain rejects it for the account sequence mismatch
+func (c *client) broadcastTxWithNonce(ctx context.Context, broadcast func(txOpt *gnfdSdkTypes.TxOption) (*tx.BroadcastTxResponse, error),
+	addr sdk.AccAddress, txOpt *gnfdSdkTypes.TxOption) (*tx.BroadcastTxResponse, error) {
+	fetch := func() (uint64, error) {
+		account, err := c.GetAccount(ctx, addr.String())
+		if err != nil {
+			return 0, err
+		}
+		return account.GetSequence(), nil
+	}
+
+	for retry := 0; ; retry++ {
+		sequence, err := c.nonces.acquire(addr, fetch)
+		if err != nil {
+			return nil, err
+		}
+		withNonce := gnfdSdkTypes.TxOption{}
+		if txOpt != nil {
+			withNonce = *txOpt
+		}
+		withNonce.Nonce = sequence
+
+		resp, err := broadcast(&withNonce)
+		expected, mismatch := sequenceMismatch(resp, err)
+		if !mismatch || retry >= maxSequenceRetries {
+			// the tx included in a block consumes the sequence even if it fails
+			if err == nil && resp.TxResponse != nil && (resp.TxResponse.Code == 0 || resp.TxResponse.Height > 0) {
+				c.nonces.commit(addr, sequence)
+			} else {
+				c.nonces.release(addr, sequence)
+			}
+			return resp, err
+		}
+
+		if expected > sequence {
+			// the sequence is stale, the txs sent by others have consumed it
+			c.nonces.resync(addr, sequence, expected)
+			continue
+		}
+		// the previous txs are not in the mempool yet, retry after them
+		c.nonces.release(addr, sequence)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(sequenceRetryBackoff):
+		}
+	}
+}
+
+// sequenceMismatch returns the sequence expected by the chain if the tx is rejected for the account sequence
+// mismatch, either by the simulation or by the broadcast
+func sequenceMismatch(resp *tx.BroadcastTxResponse, err error) (uint64, bool) {
+	var log string
+	if err != nil {
+		log = err.Error()
+	} else if resp != nil && resp.TxResponse != nil && resp.TxResponse.Code != 0 {
+		log = resp.TxResponse.RawLog
+	}
+	matches := sequenceMismatchRegexp.FindStringSubmatch(log)
+	if matches == nil {
+		return 0, false
+	}
+	expected, parseErr := strconv.ParseUint(matches[1], 10, 64)
+	if parseErr != nil {
+		return 0, false
+	}
+	return expected, true
+}
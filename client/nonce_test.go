@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestNonceManager(t *testing.T) {
+	account, _, err := types.NewAccount("uploader")
+	require.NoError(t, err)
+	addr := account.GetAddress()
+	m := newNonceManager()
+	fetched := 0
+	fetch := func() (uint64, error) {
+		fetched++
+		return 10, nil
+	}
+
+	var sequences []uint64
+	for i := 0; i < 4; i++ {
+		sequence, err := m.acquire(addr, fetch)
+		require.NoError(t, err)
+		sequences = append(sequences, sequence)
+	}
+	assert.Equal(t, []uint64{10, 11, 12, 13}, sequences)
+	assert.Equal(t, 1, fetched)
+
+	// the gap left by the failed tx is filled first
+	m.release(addr, 11)
+	m.commit(addr, 10)
+	sequence, err := m.acquire(addr, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(11), sequence)
+
+	// the last sequences are rolled back
+	m.release(addr, 13)
+	m.release(addr, 12)
+	sequence, err = m.acquire(addr, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), sequence)
+
+	// the stale sequence is moved to the one expected by the chain
+	m.resync(addr, 12, 20)
+	sequence, err = m.acquire(addr, fetch)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), sequence)
+	assert.Equal(t, 1, fetched)
+}
+
+func TestNonceManagerConcurrent(t *testing.T) {
+	account, _, err := types.NewAccount("uploader")
+	require.NoError(t, err)
+	m := newNonceManager()
+	fetch := func() (uint64, error) { return 0, nil }
+
+	var (
+		mu   sync.Mutex
+		seen = make(map[uint64]bool)
+		wg   sync.WaitGroup
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sequence, err := m.acquire(account.GetAddress(), fetch)
+			assert.NoError(t, err)
+			mu.Lock()
+			defer mu.Unlock()
+			assert.False(t, seen[sequence])
+			seen[sequence] = true
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, 50)
+}
+
+// testChain accepts the txs of an account in the order of the sequences like the mempool
+type testChain struct {
+	mu       sync.Mutex
+	sequence uint64
+}
+
+func (tc *testChain) broadcast(txOpt *gnfdSdkTypes.TxOption) (*tx.BroadcastTxResponse, error) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if txOpt.Nonce != tc.sequence {
+		return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{
+			Code:   32,
+			RawLog: fmt.Sprintf("account sequence mismatch, expected %d, got %d: incorrect account sequence", tc.sequence, txOpt.Nonce),
+		}}, nil
+	}
+	tc.sequence++
+	return &tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{TxHash: fmt.Sprint(txOpt.Nonce)}}, nil
+}
+
+func TestBroadcastTxWithNonce(t *testing.T) {
+	account, _, err := types.NewAccount("uploader")
+	require.NoError(t, err)
+	addr := account.GetAddress()
+	c := &client{nonces: newNonceManager()}
+	// the txs sent by another process have moved the sequence of the chain ahead
+	chain := &testChain{sequence: 7}
+	_, err = c.nonces.acquire(addr, func() (uint64, error) { return 3, nil })
+	require.NoError(t, err)
+	c.nonces.commit(addr, 3)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.broadcastTxWithNonce(context.Background(), chain.broadcast, addr, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, uint32(0), resp.TxResponse.Code)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, uint64(17), chain.sequence)
+
+	// the tx rejected for other reasons gives its sequence back
+	_, err = c.broadcastTxWithNonce(context.Background(), func(*gnfdSdkTypes.TxOption) (*tx.BroadcastTxResponse, error) {
+		return nil, errors.New("insufficient fees")
+	}, addr, nil)
+	assert.Error(t, err)
+	resp, err := c.broadcastTxWithNonce(context.Background(), chain.broadcast, addr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "17", resp.TxResponse.TxHash)
+}
+
+func TestSequenceMismatch(t *testing.T) {
+	expected, ok := sequenceMismatch(nil, errors.New("rpc error: code = Unknown desc = account sequence mismatch, expected 5, got 3: incorrect account sequence"))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), expected)
+	_, ok = sequenceMismatch(&tx.BroadcastTxResponse{TxResponse: &sdk.TxResponse{Code: 5, RawLog: "insufficient funds"}}, nil)
+	assert.False(t, ok)
+}
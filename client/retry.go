@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+type retryPolicyContextKey struct{}
+
+// WithRetryPolicy returns a copy of ctx with which the requests to SP are retried by the policy instead of the one
+// of the client
+func WithRetryPolicy(ctx context.Context, policy *types.RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyContextKey{}, policy)
+}
+
+// retryPolicy returns the retry policy of ctx, or the one of the client if ctx has none
+func (c *client) retryPolicy(ctx context.Context) *types.RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyContextKey{}).(*types.RetryPolicy); ok && policy != nil {
+		return policy
+	}
+	if c.defaultRetryPolicy != nil {
+		return c.defaultRetryPolicy
+	}
+	return types.DefaultRetryPolicy()
+}
+
+// isIdempotentMethod reports whether sending the request of the method many times has the same effect as once
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestRewinder returns the func rewinding the body of the request to where it starts, it returns false if the
+// request can't be sent again
+func requestRewinder(opt *sendOptions) (func() error, bool) {
+	if !isIdempotentMethod(opt.method) {
+		return nil, false
+	}
+	reader, ok := opt.body.(io.Reader)
+	if !ok {
+		// no body or a body encoded for each request
+		return func() error { return nil }, true
+	}
+	seeker, ok := reader.(io.Seeker)
+	if !ok {
+		return nil, false
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, false
+	}
+	return func() error {
+		_, err := seeker.Seek(start, io.SeekStart)
+		return err
+	}, true
+}
+
+// closedConnError explains the EOF error of the connection closed by SP
+func closedConnError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && strings.Contains(urlErr.Err.Error(), "EOF") {
+		return &url.Error{
+			Op:  urlErr.Op,
+			URL: urlErr.URL,
+			Err: errors.New("Connection closed by foreign host " + urlErr.URL + ". Retry again."),
+		}
+	}
+	return err
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// newRetryTestServer returns the SP server failing the first failures requests with 503, and the bodies of all the
+// requests it receives
+func newRetryTestServer(t *testing.T, failures int) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.NotEmpty(t, r.Header.Get(types.HTTPHeaderDate))
+		assert.NotEmpty(t, r.Header.Get(types.HTTPHeaderAuthorization))
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		n := len(bodies)
+		mu.Unlock()
+		if n <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), bodies...)
+	}
+}
+
+func TestSendReqRetry(t *testing.T) {
+	alice, _, err := types.NewAccount("alice")
+	require.NoError(t, err)
+	c := &client{defaultAccount: alice, httpClient: &http.Client{}, defaultRetryPolicy: &types.RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+	}}
+
+	server, bodies := newRetryTestServer(t, 2)
+	defer server.Close()
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	// the seekable body is rewound for each retry
+	_, err = c.sendReq(context.Background(), requestMeta{contentLength: 4},
+		&sendOptions{method: http.MethodPut, body: bytes.NewReader([]byte("data"))}, endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"data", "data", "data"}, bodies())
+
+	// the body which can't be rewound is sent once
+	server, bodies = newRetryTestServer(t, 2)
+	defer server.Close()
+	endpoint, err = url.Parse(server.URL)
+	require.NoError(t, err)
+	_, err = c.sendReq(context.Background(), requestMeta{contentLength: 4},
+		&sendOptions{method: http.MethodPut, body: io.MultiReader(strings.NewReader("data"))}, endpoint)
+	assert.Error(t, err)
+	assert.Len(t, bodies(), 1)
+
+	// the request is sent at most MaxAttempts times
+	server, bodies = newRetryTestServer(t, 5)
+	defer server.Close()
+	endpoint, err = url.Parse(server.URL)
+	require.NoError(t, err)
+	_, err = c.sendReq(context.Background(), requestMeta{}, &sendOptions{method: http.MethodGet}, endpoint)
+	assert.Error(t, err)
+	assert.Len(t, bodies(), 3)
+
+	// the policy of the context overrides the one of the client
+	server, bodies = newRetryTestServer(t, 5)
+	defer server.Close()
+	endpoint, err = url.Parse(server.URL)
+	require.NoError(t, err)
+	ctx := WithRetryPolicy(context.Background(), &types.RetryPolicy{MaxAttempts: 1})
+	_, err = c.sendReq(ctx, requestMeta{}, &sendOptions{method: http.MethodGet}, endpoint)
+	assert.Error(t, err)
+	assert.Len(t, bodies(), 1)
+}
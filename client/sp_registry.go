@@ -0,0 +1,289 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultSPCacheTTL is the time the SP endpoints and the primary SPs of the buckets are cached for
+	DefaultSPCacheTTL = 10 * time.Minute
+	// DefaultSPNegativeCacheTTL is the time an SP address missing on chain is remembered for, during which the
+	// lookups of the address fail without querying the chain
+	DefaultSPNegativeCacheTTL = time.Minute
+	// maxCachedBuckets bounds the number of the buckets whose primary SPs are cached
+	maxCachedBuckets = 10000
+	// spHealthTTL is the time the result of the health probe of an SP endpoint is cached for
+	spHealthTTL = 30 * time.Second
+	// spRefreshTimeout bounds the query of the SP endpoints shared by the concurrent lookups
+	spRefreshTimeout = 30 * time.Second
+)
+
+// cachedBucket is the primary SP of a bucket cached until expireAt
+type cachedBucket struct {
+	primarySP string
+	expireAt  time.Time
+}
+
+// spHealth is the result of the health probe of an SP endpoint
+type spHealth struct {
+	healthy   bool
+	latency   time.Duration
+	checkedAt time.Time
+}
+
+// refreshCall is a query of the endpoints in flight, which the concurrent refreshes wait for instead of querying
+// the chain again
+type refreshCall struct {
+	done chan struct{}
+	err  error
+}
+
+// spRegistry caches the endpoints of the SPs on chain and the primary SPs of the buckets, it is safe for concurrent
+// use. The endpoints are queried from chain on the first lookup, and the ones expired by the TTL are still served
+// while they are refreshed in the background. The concurrent refreshes share one query.
+type spRegistry struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+	// overrides are the static endpoints of the SP addresses, which are served instead of the ones on chain
+	overrides map[string]*url.URL
+	// fetch queries the endpoints of all the SPs from chain
+	fetch func(ctx context.Context) (map[string]*url.URL, error)
+	now   func() time.Time
+
+	mu          sync.RWMutex
+	endpoints   map[string]*url.URL
+	refreshedAt time.Time
+	refreshing  bool
+	// inflight is the running query of the endpoints, it is nil if none is running
+	inflight *refreshCall
+	// missing are the SP addresses not found on chain, mapped to the time until which they are not looked up again
+	missing map[string]time.Time
+	buckets map[string]cachedBucket
+	// health are the results of the health probes keyed by the SP endpoints
+	health map[string]spHealth
+	// inService is the endpoint of the in-service SP selected for the requests not bound to a bucket, it is
+	// selected again after inServiceExpireAt
+	inService         *url.URL
+	inServiceExpireAt time.Time
+}
+
+func newSPRegistry(ttl, negativeTTL time.Duration, overrides map[string]*url.URL,
+	fetch func(ctx context.Context) (map[string]*url.URL, error)) *spRegistry {
+	if ttl <= 0 {
+		ttl = DefaultSPCacheTTL
+	}
+	if negativeTTL <= 0 {
+		negativeTTL = DefaultSPNegativeCacheTTL
+	}
+	return &spRegistry{
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		overrides:   overrides,
+		fetch:       fetch,
+		now:         time.Now,
+		missing:     make(map[string]time.Time),
+		buckets:     make(map[string]cachedBucket),
+		health:      make(map[string]spHealth),
+	}
+}
+
+// refresh queries the endpoints from chain and replaces the cached ones, it waits for the running query instead if
+// there is one. The query runs with its own timeout rather than ctx, since it is shared by the concurrent callers
+// and the one starting it may give up first; each caller stops waiting when its ctx is done.
+func (r *spRegistry) refresh(ctx context.Context) error {
+	r.mu.Lock()
+	call := r.inflight
+	if call == nil {
+		call = &refreshCall{done: make(chan struct{})}
+		r.inflight = call
+		go r.runRefresh(call)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// runRefresh runs the query of the refresh call and wakes up its waiters
+func (r *spRegistry) runRefresh(call *refreshCall) {
+	ctx, cancel := context.WithTimeout(context.Background(), spRefreshTimeout)
+	defer cancel()
+	endpoints, err := r.fetch(ctx)
+
+	r.mu.Lock()
+	if err == nil {
+		r.endpoints = endpoints
+		r.refreshedAt = r.now()
+		// the SPs which joined since are looked up again
+		r.missing = make(map[string]time.Time)
+	}
+	r.inflight = nil
+	r.mu.Unlock()
+	call.err = err
+	close(call.done)
+}
+
+// refreshInBackground refreshes the expired endpoints unless another refresh is running, the caller must hold the lock
+func (r *spRegistry) refreshInBackground() {
+	if r.refreshing {
+		return
+	}
+	r.refreshing = true
+	go func() {
+		if err := r.refresh(context.Background()); err != nil {
+			log.Error().Msg(fmt.Sprintf("refresh the SP endpoints fail, err: %s", err))
+		}
+		r.mu.Lock()
+		r.refreshing = false
+		r.mu.Unlock()
+	}()
+}
+
+// endpoint returns the endpoint of the SP address. The endpoints are queried from chain if none is cached, or if
+// the address is not cached and it is not known to be missing.
+func (r *spRegistry) endpoint(ctx context.Context, address string) (*url.URL, error) {
+	if endpoint, ok := r.overrides[address]; ok {
+		return endpoint, nil
+	}
+	r.mu.Lock()
+	endpoint, ok := r.endpoints[address]
+	loaded := r.endpoints != nil
+	if loaded && r.now().Sub(r.refreshedAt) >= r.ttl {
+		r.refreshInBackground()
+	}
+	missingUntil, missing := r.missing[address]
+	r.mu.Unlock()
+	if ok {
+		return endpoint, nil
+	}
+	if loaded && missing && r.now().Before(missingUntil) {
+		return nil, fmt.Errorf("the SP endpoint %s not exists on chain", address)
+	}
+
+	if err := r.refresh(ctx); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if endpoint, ok = r.endpoints[address]; ok {
+		return endpoint, nil
+	}
+	r.missing[address] = r.now().Add(r.negativeTTL)
+	return nil, fmt.Errorf("the SP endpoint %s not exists on chain", address)
+}
+
+// all returns a copy of the cached endpoints of all the SPs along with the overrides, the endpoints are queried
+// from chain if none is cached
+func (r *spRegistry) all(ctx context.Context) (map[string]*url.URL, error) {
+	r.mu.Lock()
+	loaded := r.endpoints != nil
+	if loaded && r.now().Sub(r.refreshedAt) >= r.ttl {
+		r.refreshInBackground()
+	}
+	r.mu.Unlock()
+	if !loaded {
+		if err := r.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	endpoints := make(map[string]*url.URL, len(r.endpoints))
+	for address, endpoint := range r.endpoints {
+		endpoints[address] = endpoint
+	}
+	for address, endpoint := range r.overrides {
+		endpoints[address] = endpoint
+	}
+	return endpoints, nil
+}
+
+// primarySP returns the cached primary SP address of the bucket
+func (r *spRegistry) primarySP(bucketName string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	bucket, ok := r.buckets[bucketName]
+	if !ok || !r.now().Before(bucket.expireAt) {
+		return "", false
+	}
+	return bucket.primarySP, true
+}
+
+// setPrimarySP caches the primary SP address of the bucket
+func (r *spRegistry) setPrimarySP(bucketName, primarySP string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	if len(r.buckets) >= maxCachedBuckets {
+		for name, bucket := range r.buckets {
+			if !now.Before(bucket.expireAt) {
+				delete(r.buckets, name)
+			}
+		}
+		if len(r.buckets) >= maxCachedBuckets {
+			r.buckets = make(map[string]cachedBucket)
+		}
+	}
+	r.buckets[bucketName] = cachedBucket{primarySP: primarySP, expireAt: now.Add(r.ttl)}
+}
+
+// forgetBucket drops the cached primary SP of the bucket
+func (r *spRegistry) forgetBucket(bucketName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.buckets, bucketName)
+}
+
+// inServiceSP returns the cached endpoint of the selected in-service SP
+func (r *spRegistry) inServiceSP() (*url.URL, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.inService == nil || !r.now().Before(r.inServiceExpireAt) {
+		return nil, false
+	}
+	return r.inService, true
+}
+
+// setInServiceSP caches the endpoint of the selected in-service SP
+func (r *spRegistry) setInServiceSP(endpoint *url.URL) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inService = endpoint
+	r.inServiceExpireAt = r.now().Add(r.ttl)
+}
+
+// forgetInServiceSP drops the cached in-service SP, so that the SP is selected again on the next request
+func (r *spRegistry) forgetInServiceSP() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inService = nil
+}
+
+// cachedHealth returns the result of the health probe of the SP endpoint unless it expires
+func (r *spRegistry) cachedHealth(endpoint string) (spHealth, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	health, ok := r.health[endpoint]
+	if !ok || r.now().Sub(health.checkedAt) >= spHealthTTL {
+		return spHealth{}, false
+	}
+	return health, true
+}
+
+// setHealth caches the result of the health probe of the SP endpoint
+func (r *spRegistry) setHealth(endpoint string, healthy bool, latency time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.health[endpoint] = spHealth{healthy: healthy, latency: latency, checkedAt: r.now()}
+}
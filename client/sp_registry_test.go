@@ -0,0 +1,271 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestSPRegistry(t *testing.T) {
+	var fetches int32
+	endpoints := map[string]*url.URL{"sp1": {Scheme: "http", Host: "sp1"}}
+	registry := newSPRegistry(time.Minute, time.Second, nil, func(context.Context) (map[string]*url.URL, error) {
+		atomic.AddInt32(&fetches, 1)
+		copied := make(map[string]*url.URL)
+		for address, endpoint := range endpoints {
+			copied[address] = endpoint
+		}
+		return copied, nil
+	})
+	now := time.Now()
+	registry.now = func() time.Time { return now }
+
+	endpoint, err := registry.endpoint(context.Background(), "sp1")
+	require.NoError(t, err)
+	assert.Equal(t, "sp1", endpoint.Host)
+	_, err = registry.endpoint(context.Background(), "sp1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	// the missing SP is queried once until the negative cache expires
+	_, err = registry.endpoint(context.Background(), "sp2")
+	assert.Error(t, err)
+	_, err = registry.endpoint(context.Background(), "sp2")
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+	endpoints["sp2"] = &url.URL{Scheme: "http", Host: "sp2"}
+	now = now.Add(2 * time.Second)
+	endpoint, err = registry.endpoint(context.Background(), "sp2")
+	require.NoError(t, err)
+	assert.Equal(t, "sp2", endpoint.Host)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&fetches))
+
+	// the expired endpoints are served while they are refreshed in the background
+	now = now.Add(2 * time.Minute)
+	_, err = registry.endpoint(context.Background(), "sp1")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&fetches) == 4 }, time.Second, 10*time.Millisecond)
+
+	all, err := registry.all(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestSPRegistryFetchError(t *testing.T) {
+	registry := newSPRegistry(0, 0, nil, func(context.Context) (map[string]*url.URL, error) {
+		return nil, errors.New("chain unavailable")
+	})
+	_, err := registry.endpoint(context.Background(), "sp1")
+	assert.Error(t, err)
+	_, err = registry.all(context.Background())
+	assert.Error(t, err)
+}
+
+func TestSPRegistryOverrides(t *testing.T) {
+	var fetches int32
+	overrides := map[string]*url.URL{"sp1": {Scheme: "http", Host: "local-sp1"}}
+	registry := newSPRegistry(0, 0, overrides, func(context.Context) (map[string]*url.URL, error) {
+		atomic.AddInt32(&fetches, 1)
+		return map[string]*url.URL{"sp1": {Scheme: "http", Host: "sp1"}, "sp2": {Scheme: "http", Host: "sp2"}}, nil
+	})
+
+	// the overridden SP is served without querying the chain
+	endpoint, err := registry.endpoint(context.Background(), "sp1")
+	require.NoError(t, err)
+	assert.Equal(t, "local-sp1", endpoint.Host)
+	assert.Zero(t, atomic.LoadInt32(&fetches))
+
+	endpoint, err = registry.endpoint(context.Background(), "sp2")
+	require.NoError(t, err)
+	assert.Equal(t, "sp2", endpoint.Host)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	all, err := registry.all(context.Background())
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "local-sp1", all["sp1"].Host)
+}
+
+func TestSPRegistrySingleflight(t *testing.T) {
+	var fetches int32
+	release := make(chan struct{})
+	registry := newSPRegistry(0, 0, nil, func(context.Context) (map[string]*url.URL, error) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		return map[string]*url.URL{"sp1": {Scheme: "http", Host: "sp1"}}, nil
+	})
+
+	// the concurrent cold lookups share one query of the chain
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			endpoint, err := registry.endpoint(context.Background(), "sp1")
+			assert.NoError(t, err)
+			assert.Equal(t, "sp1", endpoint.Host)
+		}()
+	}
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&fetches) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+}
+
+func TestSPRegistryRefreshCanceled(t *testing.T) {
+	release := make(chan struct{})
+	registry := newSPRegistry(0, 0, nil, func(ctx context.Context) (map[string]*url.URL, error) {
+		select {
+		case <-release:
+			return map[string]*url.URL{"sp1": {Scheme: "http", Host: "sp1"}}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+
+	// the caller starting the shared query gives up, the query goes on for the other callers
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := registry.endpoint(ctx, "sp1")
+		firstErr <- err
+	}()
+	require.Eventually(t, func() bool {
+		registry.mu.RLock()
+		defer registry.mu.RUnlock()
+		return registry.inflight != nil
+	}, time.Second, time.Millisecond)
+	otherErr := make(chan error, 1)
+	go func() {
+		_, err := registry.endpoint(context.Background(), "sp1")
+		otherErr <- err
+	}()
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+	assert.NoError(t, <-otherErr)
+	endpoint, err := registry.endpoint(context.Background(), "sp1")
+	require.NoError(t, err)
+	assert.Equal(t, "sp1", endpoint.Host)
+}
+
+func TestForgetStaleBucket(t *testing.T) {
+	alice, _, err := types.NewAccount("alice")
+	require.NoError(t, err)
+	c := &client{defaultAccount: alice, httpClient: &http.Client{}, sps: newSPRegistry(0, 0, nil, nil)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("<Error><Code>NoSuchBucket</Code></Error>"))
+	}))
+	defer server.Close()
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	// the bucket is routed to the primary SP queried from chain again after the cached SP misses it
+	c.sps.setPrimarySP("bucket", "sp1")
+	_, err = c.sendReq(context.Background(), requestMeta{bucketName: "bucket"}, &sendOptions{method: http.MethodGet}, endpoint)
+	assert.Error(t, err)
+	_, ok := c.sps.primarySP("bucket")
+	assert.False(t, ok)
+}
+
+func TestSPRegistryBuckets(t *testing.T) {
+	registry := newSPRegistry(time.Minute, 0, nil, nil)
+	now := time.Now()
+	registry.now = func() time.Time { return now }
+
+	_, ok := registry.primarySP("bucket")
+	assert.False(t, ok)
+	registry.setPrimarySP("bucket", "sp1")
+	primarySP, ok := registry.primarySP("bucket")
+	require.True(t, ok)
+	assert.Equal(t, "sp1", primarySP)
+
+	registry.forgetBucket("bucket")
+	_, ok = registry.primarySP("bucket")
+	assert.False(t, ok)
+
+	registry.setPrimarySP("bucket", "sp1")
+	now = now.Add(2 * time.Minute)
+	_, ok = registry.primarySP("bucket")
+	assert.False(t, ok)
+}
+
+func TestSPRegistryInServiceSP(t *testing.T) {
+	registry := newSPRegistry(time.Minute, 0, nil, nil)
+	now := time.Now()
+	registry.now = func() time.Time { return now }
+
+	_, ok := registry.inServiceSP()
+	assert.False(t, ok)
+	registry.setInServiceSP(&url.URL{Scheme: "http", Host: "sp1"})
+	endpoint, ok := registry.inServiceSP()
+	require.True(t, ok)
+	assert.Equal(t, "sp1", endpoint.Host)
+
+	registry.forgetInServiceSP()
+	_, ok = registry.inServiceSP()
+	assert.False(t, ok)
+
+	// the SP is selected again once the TTL expires
+	registry.setInServiceSP(&url.URL{Scheme: "http", Host: "sp1"})
+	now = now.Add(2 * time.Minute)
+	_, ok = registry.inServiceSP()
+	assert.False(t, ok)
+}
+
+func TestSPRegistryConcurrent(t *testing.T) {
+	registry := newSPRegistry(time.Nanosecond, time.Nanosecond, nil, func(context.Context) (map[string]*url.URL, error) {
+		return map[string]*url.URL{"sp1": {Scheme: "http", Host: "sp1"}}, nil
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, err := registry.endpoint(context.Background(), "sp1")
+				assert.NoError(t, err)
+				registry.setPrimarySP("bucket", "sp1")
+				registry.primarySP("bucket")
+				_, err = registry.all(context.Background())
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNewWithoutSP(t *testing.T) {
+	// the SPs are not queried until the first storage call, so the client is created without a reachable chain
+	alice, _, err := types.NewAccount("alice")
+	require.NoError(t, err)
+	c, err := New("greenfield_9000-121", "http://127.0.0.1:1", Option{
+		SPEndpoints: map[string]string{alice.GetAddress().String(): "127.0.0.1:9033"},
+	})
+	require.NoError(t, err)
+
+	endpoint, err := c.(*client).getSPUrlByAddr(context.Background(), alice.GetAddress().String())
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:9033", endpoint.String())
+	_, err = c.(*client).getSPUrlByAddr(context.Background(), "0x0000000000000000000000000000000000000001")
+	assert.Error(t, err)
+
+	_, err = New("greenfield_9000-121", "http://127.0.0.1:1", Option{
+		SPEndpoints: map[string]string{"invalid": "127.0.0.1:9033"},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// SelectStorageProviders probes the endpoints of the in-service SPs and returns the healthy ones from the highest
+// score, the SPs are scored by the latency of the probe, the storage prices and the regions as the option weighs them
+func (c *client) SelectStorageProviders(ctx context.Context, opt types.SPSelectOption) ([]*types.SPCandidate, error) {
+	spList, err := c.ListStorageProviders(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	weights := types.DefaultSPScoreWeights()
+	if opt.Weights != nil {
+		weights = *opt.Weights
+	}
+	timeout := opt.ProbeTimeout
+	if timeout <= 0 {
+		timeout = types.DefaultSPProbeTimeout
+	}
+	// load the SP endpoints once before they are looked up concurrently
+	if _, err = c.sps.all(ctx); err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*types.SPCandidate, len(spList))
+	var wg sync.WaitGroup
+	for i, info := range spList {
+		candidate := &types.SPCandidate{Info: info, Regions: types.SPRegions(info.Description)}
+		candidates[i] = candidate
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			operator := candidate.Info.GetOperator().String()
+			endpoint, err := c.getSPUrlByAddr(ctx, operator)
+			if err != nil {
+				log.Debug().Msg(fmt.Sprintf("skip the SP %s, err: %s", operator, err))
+				return
+			}
+			candidate.Endpoint = endpoint
+			candidate.Healthy, candidate.Latency = c.probeSP(ctx, endpoint, timeout)
+			if !candidate.Healthy || !weights.NeedPrice() {
+				return
+			}
+			price, err := c.GetStoragePrice(ctx, operator)
+			if err != nil {
+				log.Debug().Msg(fmt.Sprintf("query the storage price of the SP %s fail, err: %s", operator, err))
+				return
+			}
+			candidate.Price = price
+		}()
+	}
+	wg.Wait()
+
+	selected := types.ScoreSPCandidates(candidates, opt.Regions, weights)
+	if len(selected) == 0 {
+		return nil, errors.New("no healthy SP in service found")
+	}
+	return selected, nil
+}
+
+// probeSP sends a request to the SP endpoint and returns whether the SP serves it and how long the SP takes. Any
+// response except a server error counts as healthy, since the request is not signed. The results are cached.
+func (c *client) probeSP(ctx context.Context, endpoint *url.URL, timeout time.Duration) (bool, time.Duration) {
+	if health, ok := c.sps.cachedHealth(endpoint.String()); ok {
+		return health.healthy, health.latency
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(probeCtx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return false, 0
+	}
+	req.Header.Set(types.HTTPHeaderUserAgent, c.userAgent)
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	latency := time.Since(start)
+	healthy := err == nil && resp.StatusCode < http.StatusInternalServerError
+	if err == nil {
+		utils.CloseResponse(resp)
+	}
+	// the probe canceled by the caller tells nothing about the SP
+	if ctx.Err() == nil {
+		c.sps.setHealth(endpoint.String(), healthy, latency)
+	}
+	return healthy, latency
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeSP(t *testing.T) {
+	var probes int32
+	status := http.StatusForbidden
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&probes, 1)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	c := &client{httpClient: &http.Client{}, sps: newSPRegistry(0, 0, nil, nil)}
+	now := time.Now()
+	c.sps.now = func() time.Time { return now }
+
+	// the unsigned probe is refused, but the SP is serving
+	healthy, latency := c.probeSP(context.Background(), endpoint, time.Second)
+	assert.True(t, healthy)
+	assert.NotZero(t, latency)
+	// the result is cached
+	healthy, _ = c.probeSP(context.Background(), endpoint, time.Second)
+	assert.True(t, healthy)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&probes))
+
+	status = http.StatusServiceUnavailable
+	now = now.Add(spHealthTTL)
+	healthy, _ = c.probeSP(context.Background(), endpoint, time.Second)
+	assert.False(t, healthy)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&probes))
+
+	// the unreachable SP is unhealthy
+	server.Close()
+	now = now.Add(spHealthTTL)
+	healthy, _ = c.probeSP(context.Background(), endpoint, time.Second)
+	assert.False(t, healthy)
+}
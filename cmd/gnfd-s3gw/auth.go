@@ -0,0 +1,274 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+const (
+	signV4Algorithm = "AWS4-HMAC-SHA256"
+	iso8601Format   = "20060102T150405Z"
+	yyyymmdd        = "20060102"
+	serviceS3       = "s3"
+
+	amzDate          = "X-Amz-Date"
+	amzContentSHA256 = "X-Amz-Content-Sha256"
+
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	streamingPayload = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+
+	// maxClockSkew is the max difference allowed between the request date and the server time
+	maxClockSkew = 15 * time.Minute
+	// maxPresignExpires is the max validity of a presigned url, 7 days
+	maxPresignExpires = 7 * 24 * time.Hour
+)
+
+// signV4Request holds the signature related fields parsed from an incoming request
+type signV4Request struct {
+	accessKey     string
+	date          time.Time
+	scopeDate     string
+	scope         string
+	region        string
+	signedHeaders []string
+	signature     string
+	payloadHash   string
+}
+
+// verifySignV4 verifies the AWS signature v4 of the request, either carried in the
+// Authorization header or in the query of a presigned url, and returns the access key
+// and the payload hash claimed by the request
+func (g *gateway) verifySignV4(r *http.Request) (string, string, error) {
+	var (
+		sr  *signV4Request
+		err error
+	)
+	if r.URL.Query().Get("X-Amz-Algorithm") != "" {
+		sr, err = parsePresignedV4(r.URL.Query())
+	} else {
+		sr, err = parseAuthHeaderV4(r)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	if sr.region != g.region {
+		return "", "", errAuthorizationHeaderMalformed
+	}
+
+	cred, ok := g.credentials[sr.accessKey]
+	if !ok {
+		return "", "", errInvalidAccessKeyID
+	}
+
+	if sr.payloadHash == streamingPayload {
+		return "", "", errNotImplemented
+	}
+
+	// the signing key is derived from the date of the request, the scope must be of the same date
+	if sr.scopeDate != sr.date.UTC().Format(yyyymmdd) {
+		return "", "", errCredentialMalformed
+	}
+
+	canonicalRequest := getCanonicalRequest(r, sr.signedHeaders, sr.payloadHash)
+	stringToSign := getStringToSign(canonicalRequest, sr.date, sr.scope)
+	signingKey := getSigningKey(cred.secretKey, sr.date, sr.region)
+	signature := hex.EncodeToString(sumHMAC(signingKey, []byte(stringToSign)))
+
+	if !hmac.Equal([]byte(signature), []byte(sr.signature)) {
+		return "", "", errSignatureDoesNotMatch
+	}
+
+	return sr.accessKey, sr.payloadHash, nil
+}
+
+// parseAuthHeaderV4 parses the header like:
+// AWS4-HMAC-SHA256 Credential=AKID/20130524/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-date, Signature=fe5f80f7
+func parseAuthHeaderV4(r *http.Request) (*signV4Request, error) {
+	auth := r.Header.Get(types.HTTPHeaderAuthorization)
+	if auth == "" {
+		return nil, errAccessDenied
+	}
+	if !strings.HasPrefix(auth, signV4Algorithm+" ") {
+		return nil, errSignatureVersionNotSupported
+	}
+
+	fields := make(map[string]string, 3)
+	for _, kv := range strings.Split(strings.TrimPrefix(auth, signV4Algorithm+" "), ",") {
+		k, v, found := strings.Cut(strings.TrimSpace(kv), "=")
+		if !found {
+			return nil, errAuthorizationHeaderMalformed
+		}
+		fields[k] = v
+	}
+
+	sr := &signV4Request{
+		signature:   fields["Signature"],
+		payloadHash: r.Header.Get(amzContentSHA256),
+	}
+	if sr.payloadHash == "" {
+		return nil, errMissingContentSHA256
+	}
+	if err := sr.parseCredential(fields["Credential"]); err != nil {
+		return nil, err
+	}
+	if err := sr.parseSignedHeaders(fields["SignedHeaders"]); err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse(iso8601Format, r.Header.Get(amzDate))
+	if err != nil {
+		return nil, errMissingDateHeader
+	}
+	if skew := time.Since(date); skew > maxClockSkew || skew < -maxClockSkew {
+		return nil, errRequestTimeTooSkewed
+	}
+	sr.date = date
+
+	return sr, nil
+}
+
+// parsePresignedV4 parses the signature fields from the query of a presigned url
+func parsePresignedV4(query url.Values) (*signV4Request, error) {
+	if query.Get("X-Amz-Algorithm") != signV4Algorithm {
+		return nil, errSignatureVersionNotSupported
+	}
+
+	sr := &signV4Request{
+		signature:   query.Get("X-Amz-Signature"),
+		payloadHash: unsignedPayload,
+	}
+	if err := sr.parseCredential(query.Get("X-Amz-Credential")); err != nil {
+		return nil, err
+	}
+	if err := sr.parseSignedHeaders(query.Get("X-Amz-SignedHeaders")); err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse(iso8601Format, query.Get(amzDate))
+	if err != nil {
+		return nil, errAuthorizationQueryMalformed
+	}
+	expires, err := strconv.ParseInt(query.Get("X-Amz-Expires"), 10, 64)
+	if err != nil || expires < 0 || time.Duration(expires)*time.Second > maxPresignExpires {
+		return nil, errAuthorizationQueryMalformed
+	}
+	if time.Now().After(date.Add(time.Duration(expires) * time.Second)) {
+		return nil, errExpiredPresignRequest
+	}
+	sr.date = date
+
+	return sr, nil
+}
+
+// parseCredential parses the credential like AKID/20130524/us-east-1/s3/aws4_request
+func (sr *signV4Request) parseCredential(credential string) error {
+	parts := strings.Split(credential, "/")
+	if len(parts) != 5 || parts[3] != serviceS3 || parts[4] != "aws4_request" {
+		return errCredentialMalformed
+	}
+	sr.accessKey = parts[0]
+	sr.scopeDate = parts[1]
+	sr.region = parts[2]
+	sr.scope = strings.Join(parts[1:], "/")
+	return nil
+}
+
+func (sr *signV4Request) parseSignedHeaders(signedHeaders string) error {
+	if signedHeaders == "" {
+		return errAuthorizationHeaderMalformed
+	}
+	sr.signedHeaders = strings.Split(signedHeaders, ";")
+	return nil
+}
+
+// getCanonicalRequest generates the canonical request of signature v4
+func getCanonicalRequest(r *http.Request, signedHeaders []string, payloadHash string) string {
+	return strings.Join([]string{
+		r.Method,
+		utils.EncodePath(r.URL.Path),
+		getCanonicalQuery(r.URL.Query()),
+		getCanonicalHeaders(r, signedHeaders),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+}
+
+// getCanonicalQuery sorts and encodes the query values, the signature itself is excluded
+func getCanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		if k == "X-Amz-Signature" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, k := range keys {
+		values := append([]string{}, query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			pairs = append(pairs, encodeQueryComponent(k)+"="+encodeQueryComponent(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// encodeQueryComponent encodes the string as RFC 3986 required by signature v4
+func encodeQueryComponent(s string) string {
+	return strings.NewReplacer("+", "%20", "*", "%2A", "%7E", "~").Replace(url.QueryEscape(s))
+}
+
+func getCanonicalHeaders(r *http.Request, signedHeaders []string) string {
+	var buf strings.Builder
+	for _, name := range signedHeaders {
+		buf.WriteString(name)
+		buf.WriteByte(':')
+		switch name {
+		case "host":
+			buf.WriteString(r.Host)
+		case "content-length":
+			buf.WriteString(strconv.FormatInt(r.ContentLength, 10))
+		default:
+			values := r.Header.Values(name)
+			for i, v := range values {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				buf.WriteString(strings.Join(strings.Fields(v), " "))
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+func getStringToSign(canonicalRequest string, date time.Time, scope string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	return signV4Algorithm + "\n" + date.Format(iso8601Format) + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+}
+
+func getSigningKey(secretKey string, date time.Time, region string) []byte {
+	dateKey := sumHMAC([]byte("AWS4"+secretKey), []byte(date.Format(yyyymmdd)))
+	regionKey := sumHMAC(dateKey, []byte(region))
+	serviceKey := sumHMAC(regionKey, []byte(serviceS3))
+	return sumHMAC(serviceKey, []byte("aws4_request"))
+}
+
+func sumHMAC(key []byte, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestSignV4Example checks the signature against the GET object example of the AWS S3 docs
+func TestSignV4Example(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://examplebucket.s3.amazonaws.com/test.txt", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Range", "bytes=0-9")
+	req.Header.Set(amzContentSHA256, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	req.Header.Set(amzDate, "20130524T000000Z")
+
+	date, _ := time.Parse(iso8601Format, "20130524T000000Z")
+	signedHeaders := []string{"host", "range", "x-amz-content-sha256", "x-amz-date"}
+	canonicalRequest := getCanonicalRequest(req, signedHeaders, req.Header.Get(amzContentSHA256))
+	stringToSign := getStringToSign(canonicalRequest, date, "20130524/us-east-1/s3/aws4_request")
+	signingKey := getSigningKey("wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY", date, "us-east-1")
+
+	signature := hex.EncodeToString(sumHMAC(signingKey, []byte(stringToSign)))
+	if signature != "f0e8bdb87c964420e857bd35b5d6ed310bd44f0170aba48dd91039c6036bdb41" {
+		t.Errorf("unexpected signature %s", signature)
+	}
+}
+
+func TestVerifySignV4ScopeDate(t *testing.T) {
+	g := &gateway{region: "us-east-1", credentials: map[string]*credential{"AKID": {secretKey: "secret"}}}
+	now := time.Now().UTC()
+	cases := []struct {
+		scopeDate string
+		err       error
+	}{
+		{now.Format(yyyymmdd), errSignatureDoesNotMatch},
+		{now.AddDate(0, 0, -1).Format(yyyymmdd), errCredentialMalformed},
+	}
+	for _, c := range cases {
+		req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1:9000/bucket/object", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(amzContentSHA256, unsignedPayload)
+		req.Header.Set(amzDate, now.Format(iso8601Format))
+		req.Header.Set("Authorization", signV4Algorithm+" Credential=AKID/"+c.scopeDate+
+			"/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-date, Signature=00")
+
+		if _, _, err = g.verifySignV4(req); err != c.err {
+			t.Errorf("scope date %s: got %v, expect %v", c.scopeDate, err, c.err)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		header     string
+		start, end int64
+		valid      bool
+	}{
+		{"bytes=0-9", 0, 9, true},
+		{"bytes=10-", 10, 99, true},
+		{"bytes=-10", 90, 99, true},
+		{"bytes=90-200", 90, 99, true},
+		{"bytes=-200", 0, 99, true},
+		{"bytes=100-", 0, 0, false},
+		{"bytes=9-0", 0, 0, false},
+		{"bytes=0-1,3-4", 0, 0, false},
+		{"items=0-9", 0, 0, false},
+	}
+	for _, c := range cases {
+		start, end, err := parseRange(c.header, 100)
+		if !c.valid {
+			if err == nil {
+				t.Errorf("%s: expect error", c.header)
+			}
+			continue
+		}
+		if err != nil || start != c.start || end != c.end {
+			t.Errorf("%s: got %d-%d, %v", c.header, start, end, err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/pelletier/go-toml"
+)
+
+const (
+	defaultListenAddr = ":9000"
+	defaultRegion     = "us-east-1"
+	// defaultMaxObjectSize is the max size of a single PUT of S3, 5 GiB
+	defaultMaxObjectSize = 5 << 30
+)
+
+// Config defines the configuration of the S3 gateway
+type Config struct {
+	// ListenAddr is the address the gateway serves the S3 REST API on
+	ListenAddr string `toml:"ListenAddr"`
+	// ChainID is the chain id of greenfield
+	ChainID string `toml:"ChainID"`
+	// RpcAddr is the tendermint rpc address of the greenfield node, e.g. http://localhost:26750
+	RpcAddr string `toml:"RpcAddr"`
+	// Region is the region name S3 clients sign their requests with
+	Region string `toml:"Region"`
+	// Domain enables virtual-hosted style requests, e.g. bucket.Domain
+	Domain string `toml:"Domain"`
+	// PrimarySP is the HEX-encoded operator address of the SP new buckets are created on,
+	// the first in-service SP is used if it is empty
+	PrimarySP string `toml:"PrimarySP"`
+	// MaxObjectSize is the max size in bytes of the objects uploaded by PutObject, 5 GiB by default
+	MaxObjectSize int64 `toml:"MaxObjectSize"`
+	// Credentials maps the S3 access keys to greenfield accounts
+	Credentials []Credential `toml:"Credentials"`
+}
+
+// Credential binds an S3 access key pair to the private key of a greenfield account
+type Credential struct {
+	AccessKey  string `toml:"AccessKey"`
+	SecretKey  string `toml:"SecretKey"`
+	PrivateKey string `toml:"PrivateKey"`
+}
+
+// LoadConfig reads the gateway config from the toml file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err = toml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse config file %s failed: %w", path, err)
+	}
+
+	if cfg.ListenAddr == "" {
+		cfg.ListenAddr = defaultListenAddr
+	}
+	if cfg.Region == "" {
+		cfg.Region = defaultRegion
+	}
+	if cfg.MaxObjectSize <= 0 {
+		cfg.MaxObjectSize = defaultMaxObjectSize
+	}
+
+	return cfg, cfg.validate()
+}
+
+func (c *Config) validate() error {
+	if c.ChainID == "" || c.RpcAddr == "" {
+		return errors.New("ChainID and RpcAddr must be set")
+	}
+	if len(c.Credentials) == 0 {
+		return errors.New("at least one credential must be set")
+	}
+	seen := make(map[string]bool, len(c.Credentials))
+	for _, cred := range c.Credentials {
+		if cred.AccessKey == "" || cred.SecretKey == "" || cred.PrivateKey == "" {
+			return errors.New("AccessKey, SecretKey and PrivateKey of credential must be set")
+		}
+		if seen[cred.AccessKey] {
+			return fmt.Errorf("duplicate access key %s", cred.AccessKey)
+		}
+		seen[cred.AccessKey] = true
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+
+	gnfdErrors "github.com/bnb-chain/greenfield/types/errors"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// s3Error defines an S3 API error code with its http status
+type s3Error struct {
+	Code       string
+	Message    string
+	StatusCode int
+}
+
+func (e *s3Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+var (
+	errAccessDenied                 = &s3Error{"AccessDenied", "Access Denied.", http.StatusForbidden}
+	errInvalidAccessKeyID           = &s3Error{"InvalidAccessKeyId", "The access key ID you provided does not exist in our records.", http.StatusForbidden}
+	errSignatureDoesNotMatch        = &s3Error{"SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided.", http.StatusForbidden}
+	errSignatureVersionNotSupported = &s3Error{"AccessDenied", "Only AWS signature version 4 is supported.", http.StatusForbidden}
+	errAuthorizationHeaderMalformed = &s3Error{"AuthorizationHeaderMalformed", "The authorization header is malformed.", http.StatusBadRequest}
+	errAuthorizationQueryMalformed  = &s3Error{"AuthorizationQueryParametersError", "The authorization query parameters are malformed.", http.StatusBadRequest}
+	errCredentialMalformed          = &s3Error{"AuthorizationHeaderMalformed", "The credential is malformed.", http.StatusBadRequest}
+	errMissingDateHeader            = &s3Error{"AccessDenied", "AWS authentication requires a valid Date or x-amz-date header.", http.StatusForbidden}
+	errMissingContentSHA256         = &s3Error{"InvalidRequest", "Missing required header for this request: x-amz-content-sha256.", http.StatusBadRequest}
+	errRequestTimeTooSkewed         = &s3Error{"RequestTimeTooSkewed", "The difference between the request time and the server's time is too large.", http.StatusForbidden}
+	errExpiredPresignRequest        = &s3Error{"AccessDenied", "Request has expired.", http.StatusForbidden}
+	errContentSHA256Mismatch        = &s3Error{"XAmzContentSHA256Mismatch", "The provided 'x-amz-content-sha256' header does not match what was computed.", http.StatusBadRequest}
+	errEntityTooLarge               = &s3Error{"EntityTooLarge", "Your proposed upload exceeds the maximum allowed object size.", http.StatusRequestEntityTooLarge}
+	errInvalidRange                 = &s3Error{"InvalidRange", "The requested range is not satisfiable.", http.StatusRequestedRangeNotSatisfiable}
+	errInvalidArgument              = &s3Error{"InvalidArgument", "Invalid argument.", http.StatusBadRequest}
+	errMethodNotAllowed             = &s3Error{"MethodNotAllowed", "The specified method is not allowed against this resource.", http.StatusMethodNotAllowed}
+	errNotImplemented               = &s3Error{"NotImplemented", "A header or query you provided implies functionality that is not implemented.", http.StatusNotImplemented}
+	errNoSuchBucket                 = &s3Error{"NoSuchBucket", "The specified bucket does not exist.", http.StatusNotFound}
+	errNoSuchKey                    = &s3Error{"NoSuchKey", "The specified key does not exist.", http.StatusNotFound}
+	errBucketAlreadyExists          = &s3Error{"BucketAlreadyExists", "The requested bucket name is not available.", http.StatusConflict}
+	errBucketNotEmpty               = &s3Error{"BucketNotEmpty", "The bucket you tried to delete is not empty.", http.StatusConflict}
+	errOperationAborted             = &s3Error{"OperationAborted", "A conflicting operation is currently in progress against this resource, please try again.", http.StatusConflict}
+	errInternalError                = &s3Error{"InternalError", "We encountered an internal error, please try again.", http.StatusInternalServerError}
+)
+
+// errorResponse is the xml body of an S3 error response
+type errorResponse struct {
+	XMLName   xml.Name `xml:"Error"`
+	Code      string   `xml:"Code"`
+	Message   string   `xml:"Message"`
+	Resource  string   `xml:"Resource"`
+	RequestId string   `xml:"RequestId"`
+}
+
+// toS3Error converts the errors returned by the SDK to S3 API errors
+func toS3Error(err error) *s3Error {
+	var s3Err *s3Error
+	if errors.As(err, &s3Err) {
+		return s3Err
+	}
+
+	// the error responses returned by SP
+	var spErr types.ErrResponse
+	if errors.As(err, &spErr) {
+		switch spErr.StatusCode {
+		case http.StatusNotFound:
+			if spErr.Code == "NoSuchBucket" {
+				return errNoSuchBucket
+			}
+			return errNoSuchKey
+		case http.StatusForbidden:
+			return errAccessDenied
+		case http.StatusRequestedRangeNotSatisfiable:
+			return errInvalidRange
+		}
+		return &s3Error{spErr.Code, spErr.Message, spErr.StatusCode}
+	}
+
+	if errors.Is(err, gnfdErrors.ErrInvalidBucketName) {
+		return &s3Error{"InvalidBucketName", err.Error(), http.StatusBadRequest}
+	}
+	if errors.Is(err, gnfdErrors.ErrInvalidObjectName) {
+		return &s3Error{errInvalidArgument.Code, err.Error(), http.StatusBadRequest}
+	}
+
+	// the errors returned by chain are transferred by grpc, only the message can be matched
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, storageTypes.ErrNoSuchBucket.Error()):
+		return errNoSuchBucket
+	case strings.Contains(msg, storageTypes.ErrNoSuchObject.Error()):
+		return errNoSuchKey
+	case strings.Contains(msg, storageTypes.ErrBucketAlreadyExists.Error()):
+		return errBucketAlreadyExists
+	case strings.Contains(msg, storageTypes.ErrBucketNotEmpty.Error()):
+		return errBucketNotEmpty
+	case strings.Contains(msg, storageTypes.ErrObjectAlreadyExists.Error()),
+		strings.Contains(msg, storageTypes.ErrObjectNotSealed.Error()):
+		// another request is writing the object at the same time
+		return errOperationAborted
+	}
+	return &s3Error{errInternalError.Code, msg, errInternalError.StatusCode}
+}
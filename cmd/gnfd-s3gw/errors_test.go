@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func TestToS3Error(t *testing.T) {
+	cases := []struct {
+		err        error
+		code       string
+		statusCode int
+	}{
+		{errEntityTooLarge, "EntityTooLarge", http.StatusRequestEntityTooLarge},
+		{types.ErrResponse{StatusCode: http.StatusNotFound, Code: "NoSuchBucket"}, "NoSuchBucket", http.StatusNotFound},
+		{types.ErrResponse{StatusCode: http.StatusNotFound}, "NoSuchKey", http.StatusNotFound},
+		{fmt.Errorf("rpc error: %s", storageTypes.ErrNoSuchObject), "NoSuchKey", http.StatusNotFound},
+		{fmt.Errorf("rpc error: %s", storageTypes.ErrObjectAlreadyExists), "OperationAborted", http.StatusConflict},
+		{fmt.Errorf("rpc error: %s", storageTypes.ErrObjectNotSealed), "OperationAborted", http.StatusConflict},
+		{fmt.Errorf("unknown"), "InternalError", http.StatusInternalServerError},
+	}
+	for _, c := range cases {
+		s3Err := toS3Error(c.err)
+		if s3Err.Code != c.code || s3Err.StatusCode != c.statusCode {
+			t.Errorf("%v is converted to %s %d", c.err, s3Err.Code, s3Err.StatusCode)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+const headerRequestID = "X-Amz-Request-Id"
+
+// credential is the secret key and the greenfield account of an access key
+type credential struct {
+	secretKey string
+	account   *types.Account
+}
+
+// gateway translates the S3 REST API into greenfield SDK calls
+type gateway struct {
+	region    string
+	domain    string
+	primarySP string
+	// maxObjectSize is the max size of the payload of PutObject
+	maxObjectSize int64
+	client        client.Client
+	credentials   map[string]*credential
+}
+
+// newGateway creates the greenfield client shared by all the configured credentials,
+// the requests are sent with the account of their access key
+func newGateway(cfg *Config) (*gateway, error) {
+	g := &gateway{
+		region:        cfg.Region,
+		domain:        cfg.Domain,
+		primarySP:     cfg.PrimarySP,
+		maxObjectSize: cfg.MaxObjectSize,
+		credentials:   make(map[string]*credential, len(cfg.Credentials)),
+	}
+
+	accountRegister, err := types.NewAccountRegister()
+	if err != nil {
+		return nil, err
+	}
+	for _, cred := range cfg.Credentials {
+		account, err := types.NewAccountFromPrivateKey(cred.AccessKey, cred.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("load account of access key %s failed: %w", cred.AccessKey, err)
+		}
+		if err = accountRegister.Add(account); err != nil {
+			return nil, err
+		}
+		g.credentials[cred.AccessKey] = &credential{secretKey: cred.SecretKey, account: account}
+	}
+
+	g.client, err = client.New(cfg.ChainID, cfg.RpcAddr, client.Option{AccountRegister: accountRegister})
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// ServeHTTP authenticates the request and routes it to the handler of the S3 operation
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(headerRequestID, newRequestID())
+	w.Header().Set("Server", "gnfd-s3gw")
+
+	accessKey, payloadHash, err := g.verifySignV4(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	account := g.credentials[accessKey].account
+	req := &s3Request{
+		ctx:         client.WithAccount(r.Context(), account),
+		client:      g.client,
+		account:     account,
+		payloadHash: payloadHash,
+	}
+	req.bucketName, req.objectName = g.parseBucketAndObject(r)
+
+	query := r.URL.Query()
+	switch {
+	case req.bucketName == "":
+		if r.Method != http.MethodGet {
+			err = errMethodNotAllowed
+			break
+		}
+		err = g.listBuckets(w, req)
+	case req.objectName == "":
+		switch r.Method {
+		case http.MethodGet:
+			if query.Get("list-type") != "2" {
+				err = errNotImplemented
+				break
+			}
+			err = g.listObjectsV2(w, r, req)
+		case http.MethodHead:
+			err = g.headBucket(w, req)
+		case http.MethodPut:
+			err = g.createBucket(w, req)
+		case http.MethodDelete:
+			err = g.deleteBucket(w, req)
+		default:
+			err = errMethodNotAllowed
+		}
+	default:
+		// multipart upload and copy are not supported
+		if query.Has("uploads") || query.Has("uploadId") || r.Header.Get("X-Amz-Copy-Source") != "" {
+			err = errNotImplemented
+			break
+		}
+		switch r.Method {
+		case http.MethodGet:
+			err = g.getObject(w, r, req)
+		case http.MethodHead:
+			err = g.headObject(w, req)
+		case http.MethodPut:
+			err = g.putObject(w, r, req)
+		case http.MethodDelete:
+			err = g.deleteObject(w, req)
+		default:
+			err = errMethodNotAllowed
+		}
+	}
+
+	if err != nil {
+		writeError(w, r, err)
+	}
+}
+
+// s3Request holds the parsed info of an authenticated request
+type s3Request struct {
+	ctx         context.Context
+	client      client.Client
+	account     *types.Account
+	bucketName  string
+	objectName  string
+	payloadHash string
+}
+
+// parseBucketAndObject gets the bucket and object name from a virtual-hosted style
+// request if the host is a sub domain of the configured domain, or from a path style request
+func (g *gateway) parseBucketAndObject(r *http.Request) (string, string) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+
+	if g.domain != "" {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if strings.HasSuffix(host, "."+g.domain) {
+			return strings.TrimSuffix(host, "."+g.domain), path
+		}
+	}
+
+	bucketName, objectName, _ := strings.Cut(path, "/")
+	return bucketName, objectName
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return strings.ToUpper(hex.EncodeToString(b))
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+const maxListKeys = 1000
+
+// listBuckets handles ListBuckets, it lists the buckets owned by the account of the access key
+func (g *gateway) listBuckets(w http.ResponseWriter, req *s3Request) error {
+	listResult, err := req.client.ListBuckets(req.ctx)
+	if err != nil {
+		return err
+	}
+
+	result := listAllMyBucketsResult{
+		Xmlns: s3Namespace,
+		Owner: owner{ID: req.account.GetAddress().String(), DisplayName: req.account.GetAddress().String()},
+	}
+	for _, bucketMeta := range listResult.Buckets {
+		if bucketMeta.Removed || bucketMeta.BucketInfo == nil {
+			continue
+		}
+		result.Buckets = append(result.Buckets, bucket{
+			Name:         bucketMeta.BucketInfo.BucketName,
+			CreationDate: time.Unix(bucketMeta.BucketInfo.CreateAt, 0).UTC().Format(timeFormatAMZ),
+		})
+	}
+
+	writeXML(w, http.StatusOK, result)
+	return nil
+}
+
+// headBucket handles HeadBucket
+func (g *gateway) headBucket(w http.ResponseWriter, req *s3Request) error {
+	if _, err := req.client.HeadBucket(req.ctx, req.bucketName); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// createBucket handles CreateBucket, the bucket is created on the configured primary SP
+// or on the first in-service SP
+func (g *gateway) createBucket(w http.ResponseWriter, req *s3Request) error {
+	primarySP := g.primarySP
+	if primarySP == "" {
+		spList, err := req.client.ListStorageProviders(req.ctx, true)
+		if err != nil {
+			return err
+		}
+		if len(spList) == 0 {
+			return errors.New("no in-service SP found on chain")
+		}
+		primarySP = spList[0].OperatorAddress
+	}
+
+	txHash, err := req.client.CreateBucket(req.ctx, req.bucketName, primarySP, types.CreateBucketOptions{})
+	if err != nil {
+		return err
+	}
+	if _, err = req.client.WaitForTxSuccess(req.ctx, txHash); err != nil {
+		return err
+	}
+
+	w.Header().Set("Location", "/"+req.bucketName)
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// deleteBucket handles DeleteBucket
+func (g *gateway) deleteBucket(w http.ResponseWriter, req *s3Request) error {
+	txHash, err := req.client.DeleteBucket(req.ctx, req.bucketName, types.DeleteBucketOption{})
+	if err != nil {
+		return err
+	}
+	if _, err = req.client.WaitForTxSuccess(req.ctx, txHash); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// listObjectsV2 handles ListObjectsV2, the SP returns all the objects of the bucket,
+// so the prefix, delimiter and pagination are applied by the gateway
+func (g *gateway) listObjectsV2(w http.ResponseWriter, r *http.Request, req *s3Request) error {
+	query := r.URL.Query()
+	prefix := query.Get("prefix")
+	delimiter := query.Get("delimiter")
+	startAfter := query.Get("start-after")
+	continuationToken := query.Get("continuation-token")
+	fetchOwner := query.Get("fetch-owner") == "true"
+
+	maxKeys := maxListKeys
+	if v := query.Get("max-keys"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return errInvalidArgument
+		}
+		if n < maxKeys {
+			maxKeys = n
+		}
+	}
+
+	// the continuation token is the base64 encoded last key of the previous page
+	marker := startAfter
+	if continuationToken != "" {
+		decoded, err := base64.StdEncoding.DecodeString(continuationToken)
+		if err != nil {
+			return errInvalidArgument
+		}
+		marker = string(decoded)
+	}
+
+	listResult, err := req.client.ListObjects(req.ctx, req.bucketName, types.ListObjectsOptions{})
+	if err != nil {
+		return err
+	}
+
+	objects := make([]*types.ObjectInfo, 0, len(listResult.Objects))
+	for _, objectMeta := range listResult.Objects {
+		if objectMeta.ObjectInfo == nil || !strings.HasPrefix(objectMeta.ObjectInfo.ObjectName, prefix) {
+			continue
+		}
+		objects = append(objects, objectMeta.ObjectInfo)
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].ObjectName < objects[j].ObjectName
+	})
+
+	result := listBucketV2Result{
+		Xmlns:             s3Namespace,
+		Name:              req.bucketName,
+		Prefix:            prefix,
+		StartAfter:        startAfter,
+		ContinuationToken: continuationToken,
+		MaxKeys:           maxKeys,
+		Delimiter:         delimiter,
+	}
+
+	var lastKey string
+	seenPrefixes := make(map[string]bool)
+	for _, objectInfo := range objects {
+		key := objectInfo.ObjectName
+		if key <= marker {
+			continue
+		}
+
+		// the keys sharing the same part between prefix and delimiter are rolled up into a common prefix
+		commonPrefixStr := ""
+		if delimiter != "" {
+			if idx := strings.Index(key[len(prefix):], delimiter); idx >= 0 {
+				commonPrefixStr = key[:len(prefix)+idx+len(delimiter)]
+			}
+		}
+		if commonPrefixStr != "" && seenPrefixes[commonPrefixStr] {
+			continue
+		}
+
+		if result.KeyCount == maxKeys {
+			result.IsTruncated = true
+			result.NextContinuationToken = base64.StdEncoding.EncodeToString([]byte(lastKey))
+			break
+		}
+
+		if commonPrefixStr != "" {
+			seenPrefixes[commonPrefixStr] = true
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: commonPrefixStr})
+			// skip the rest keys of the common prefix on the next page
+			lastKey = commonPrefixStr + "\xff"
+		} else {
+			item := object{
+				Key:          key,
+				LastModified: time.Unix(objectInfo.CreateAt, 0).UTC().Format(timeFormatAMZ),
+				ETag:         getETag(objectInfo.Checksums),
+				Size:         objectInfo.PayloadSize,
+				StorageClass: "STANDARD",
+			}
+			if fetchOwner {
+				item.Owner = &owner{ID: objectInfo.Owner, DisplayName: objectInfo.Owner}
+			}
+			result.Contents = append(result.Contents, item)
+			lastKey = key
+		}
+		result.KeyCount++
+	}
+
+	writeXML(w, http.StatusOK, result)
+	return nil
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/rs/zerolog/log"
+
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/objectutil"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// getETag uses the integrity hash of the primary SP as ETag, greenfield does not keep the md5 of objects
+func getETag(checksums [][]byte) string {
+	if len(checksums) == 0 {
+		return `""`
+	}
+	return `"` + hex.EncodeToString(checksums[0]) + `"`
+}
+
+func setObjectHeaders(w http.ResponseWriter, objectInfo *storageTypes.ObjectInfo) {
+	w.Header().Set("Content-Type", objectInfo.ContentType)
+	w.Header().Set("ETag", getETag(objectInfo.Checksums))
+	w.Header().Set("Last-Modified", time.Unix(objectInfo.CreateAt, 0).UTC().Format(http.TimeFormat))
+	w.Header().Set("Accept-Ranges", "bytes")
+}
+
+// headObject handles HeadObject, the object meta is queried from chain
+func (g *gateway) headObject(w http.ResponseWriter, req *s3Request) error {
+	objectInfo, err := req.client.HeadObject(req.ctx, req.bucketName, req.objectName)
+	if err != nil {
+		return err
+	}
+
+	setObjectHeaders(w, objectInfo)
+	w.Header().Set("Content-Length", strconv.FormatUint(objectInfo.PayloadSize, 10))
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// getObject handles GetObject, a single byte range is supported
+func (g *gateway) getObject(w http.ResponseWriter, r *http.Request, req *s3Request) error {
+	objectInfo, err := req.client.HeadObject(req.ctx, req.bucketName, req.objectName)
+	if err != nil {
+		return err
+	}
+
+	size := int64(objectInfo.PayloadSize)
+	start, end := int64(0), size-1
+	isRange := false
+	if rangeHeader := r.Header.Get(types.HTTPHeaderRange); rangeHeader != "" && size > 0 {
+		start, end, err = parseRange(rangeHeader, size)
+		if err != nil {
+			return err
+		}
+		isRange = true
+	}
+
+	if size == 0 {
+		setObjectHeaders(w, objectInfo)
+		w.Header().Set("Content-Length", "0")
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+
+	opts := types.GetObjectOption{}
+	if isRange {
+		if err = opts.SetRange(start, end); err != nil {
+			return err
+		}
+	}
+	body, _, err := req.client.GetObject(req.ctx, req.bucketName, req.objectName, opts)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// the headers are set once the payload is available, otherwise the error response would be sent with them
+	setObjectHeaders(w, objectInfo)
+	w.Header().Set("Content-Length", strconv.FormatInt(end-start+1, 10))
+	if isRange {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+		w.WriteHeader(http.StatusPartialContent)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	// the status has been sent, errors can only be logged from here on
+	if _, err = io.Copy(w, body); err != nil {
+		log.Error().Msg("copy the payload of " + r.URL.Path + " failed: " + err.Error())
+	}
+	return nil
+}
+
+// parseRange parses the range header of the forms bytes=start-end, bytes=start- and bytes=-suffix
+func parseRange(rangeHeader string, size int64) (int64, int64, error) {
+	if !strings.HasPrefix(rangeHeader, "bytes=") {
+		return 0, 0, errInvalidRange
+	}
+	spec := strings.TrimPrefix(rangeHeader, "bytes=")
+	if strings.Contains(spec, ",") {
+		return 0, 0, errInvalidRange
+	}
+	startStr, endStr, found := strings.Cut(spec, "-")
+	if !found {
+		return 0, 0, errInvalidRange
+	}
+
+	if startStr == "" {
+		suffix, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || suffix <= 0 {
+			return 0, 0, errInvalidRange
+		}
+		if suffix > size {
+			suffix = size
+		}
+		return size - suffix, size - 1, nil
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, errInvalidRange
+	}
+	end := size - 1
+	if endStr != "" {
+		end, err = strconv.ParseInt(endStr, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, errInvalidRange
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	return start, end, nil
+}
+
+// putObject handles PutObject as a full upload, it creates the object on chain, waits for the
+// txn to be committed and then uploads the payload to the primary SP, an existing object is
+// overwritten. The payload is spooled to a temp file since it is read more than once, to compute
+// the integrity hash and to upload, so its size is limited by maxObjectSize.
+func (g *gateway) putObject(w http.ResponseWriter, r *http.Request, req *s3Request) error {
+	if r.ContentLength > g.maxObjectSize {
+		return errEntityTooLarge
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, g.maxObjectSize)
+
+	tmpFile, err := os.CreateTemp("", "gnfd-s3gw-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+	}()
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmpFile, hasher), r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return errEntityTooLarge
+		}
+		return err
+	}
+	if req.payloadHash != unsignedPayload && req.payloadHash != hex.EncodeToString(hasher.Sum(nil)) {
+		return errContentSHA256Mismatch
+	}
+
+	// objects on greenfield can not be overwritten, an existing object is replaced through a staging object
+	err = objectutil.Put(req.ctx, req.client, req.bucketName, req.objectName, tmpFile, size,
+		types.CreateObjectOptions{ContentType: r.Header.Get(types.HTTPHeaderContentType)})
+	if err != nil {
+		return err
+	}
+
+	objectInfo, err := req.client.HeadObject(req.ctx, req.bucketName, req.objectName)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("ETag", getETag(objectInfo.Checksums))
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// deleteObject handles DeleteObject, an object left unsealed by a failed upload is canceled
+func (g *gateway) deleteObject(w http.ResponseWriter, req *s3Request) error {
+	if err := objectutil.Remove(req.ctx, req.client, req.bucketName, req.objectName); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,40 @@
+// gnfd-s3gw serves a subset of the S3 REST API on top of greenfield, so that existing S3 tools
+// can read and write greenfield buckets and objects, e.g.
+//
+//	gnfd-s3gw -config config.toml
+//	aws --endpoint-url http://127.0.0.1:9000 s3 cp ./file s3://bucket/file
+//
+// The supported operations are ListBuckets, HeadBucket, CreateBucket, DeleteBucket, ListObjectsV2,
+// HeadObject, GetObject, PutObject and DeleteObject, requests must be signed with signature v4.
+package main
+
+import (
+	"flag"
+	"net/http"
+	"os"
+
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	configPath := flag.String("config", "config.toml", "the path of the gateway config file")
+	flag.Parse()
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		log.Error().Msg("load config failed: " + err.Error())
+		os.Exit(1)
+	}
+
+	g, err := newGateway(cfg)
+	if err != nil {
+		log.Error().Msg("init gateway failed: " + err.Error())
+		os.Exit(1)
+	}
+
+	log.Info().Msg("gnfd-s3gw is listening on " + cfg.ListenAddr)
+	if err = http.ListenAndServe(cfg.ListenAddr, g); err != nil {
+		log.Error().Msg("serve failed: " + err.Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,128 @@
+// gnfd-webdav serves the buckets of a greenfield account over WebDAV, so that they can be mounted
+// and browsed by the file managers of macOS, Windows and Linux, e.g.
+//
+//	gnfd-webdav -config config.toml
+//
+// Requests must be authenticated with the Username and Password of the config, the server refuses
+// to start without them unless -insecure-no-auth is set.
+//
+// Buckets are the top level directories and have to be created by other tools, the objects and
+// folders inside them can be listed, downloaded, uploaded, created and deleted. Moving objects is
+// not supported by greenfield.
+package main
+
+import (
+	"crypto/subtle"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/pelletier/go-toml"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/webdav"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/davfs"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+const defaultListenAddr = "127.0.0.1:8080"
+
+// Config defines the configuration of the WebDAV server
+type Config struct {
+	// ListenAddr is the address the WebDAV server listens on, 127.0.0.1:8080 by default
+	ListenAddr string `toml:"ListenAddr"`
+	// ChainID is the chain id of greenfield
+	ChainID string `toml:"ChainID"`
+	// RpcAddr is the tendermint rpc address of the greenfield node, e.g. http://localhost:26750
+	RpcAddr string `toml:"RpcAddr"`
+	// PrivateKey is the HEX-encoded private key of the account the buckets belong to
+	PrivateKey string `toml:"PrivateKey"`
+	// Username and Password are the credentials of the basic authentication
+	Username string `toml:"Username"`
+	Password string `toml:"Password"`
+	// TempDir is the directory the uploaded files are spooled to, the system temp dir by default
+	TempDir string `toml:"TempDir"`
+}
+
+// LoadConfig reads the server config from the toml file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err = toml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse config file %s failed: %w", path, err)
+	}
+	if cfg.ListenAddr == "" {
+		cfg.ListenAddr = defaultListenAddr
+	}
+	if cfg.ChainID == "" || cfg.RpcAddr == "" || cfg.PrivateKey == "" {
+		return nil, errors.New("ChainID, RpcAddr and PrivateKey must be set")
+	}
+	return cfg, nil
+}
+
+func main() {
+	configPath := flag.String("config", "config.toml", "the path of the WebDAV server config file")
+	insecureNoAuth := flag.Bool("insecure-no-auth", false,
+		"serve without authentication, anyone reaching the listen address can modify the buckets")
+	flag.Parse()
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		log.Error().Msg("load config failed: " + err.Error())
+		os.Exit(1)
+	}
+	if cfg.Username == "" && !*insecureNoAuth {
+		log.Error().Msg("Username and Password must be set, or -insecure-no-auth to serve without authentication")
+		os.Exit(1)
+	}
+
+	account, err := types.NewAccountFromPrivateKey("webdav", cfg.PrivateKey)
+	if err != nil {
+		log.Error().Msg("load account failed: " + err.Error())
+		os.Exit(1)
+	}
+	cli, err := client.New(cfg.ChainID, cfg.RpcAddr, client.Option{DefaultAccount: account})
+	if err != nil {
+		log.Error().Msg("init client failed: " + err.Error())
+		os.Exit(1)
+	}
+
+	var handler http.Handler = &webdav.Handler{
+		FileSystem: davfs.New(cli, cfg.TempDir),
+		LockSystem: webdav.NewMemLS(),
+		Logger: func(r *http.Request, err error) {
+			if err != nil {
+				log.Error().Msg(r.Method + " " + r.URL.Path + " failed: " + err.Error())
+			}
+		},
+	}
+	if cfg.Username != "" {
+		handler = basicAuth(handler, cfg.Username, cfg.Password)
+	}
+
+	log.Info().Msg("gnfd-webdav is listening on " + cfg.ListenAddr)
+	if err = http.ListenAndServe(cfg.ListenAddr, handler); err != nil {
+		log.Error().Msg("serve failed: " + err.Error())
+		os.Exit(1)
+	}
+}
+
+func basicAuth(next http.Handler, username, password string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || subtle.ConstantTimeCompare([]byte(user), []byte(username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(pass), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="greenfield"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// newClient creates the client with the chain info of the config file, the account is loaded
+// from the keystore if withAccount is set
+func newClient(c *cli.Context, withAccount bool) (client.Client, error) {
+	cfg, err := LoadConfig(c.String(flagConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	option := client.Option{Secure: cfg.Secure, Host: cfg.Host}
+	if cfg.FeeGranter != "" {
+		option.FeeGranter, err = sdk.AccAddressFromHexUnsafe(cfg.FeeGranter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FeeGranter %s: %w", cfg.FeeGranter, err)
+		}
+	}
+	if withAccount {
+		keystorePath := cfg.Keystore
+		if c.IsSet(flagKeystore) {
+			keystorePath = c.String(flagKeystore)
+		}
+		passwordFile := cfg.PasswordFile
+		if c.IsSet(flagPasswordFile) {
+			passwordFile = c.String(flagPasswordFile)
+		}
+		option.DefaultAccount, err = loadAccount(keystorePath, passwordFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return client.New(cfg.ChainID, cfg.RpcAddr, option)
+}
+
+// loadAccount decrypts the keystore file, the password is read from passwordFile or prompted
+func loadAccount(keystorePath, passwordFile string) (*types.Account, error) {
+	if keystorePath == "" {
+		return nil, errors.New("the keystore is not set, set it in the config file or by --" + flagKeystore)
+	}
+	var password string
+	if passwordFile != "" {
+		data, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return nil, fmt.Errorf("read password file %s failed: %w", passwordFile, err)
+		}
+		password = strings.TrimRight(string(data), "\r\n")
+	} else {
+		fmt.Fprint(os.Stderr, "Password: ")
+		data, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, fmt.Errorf("read password failed: %w", err)
+		}
+		password = string(data)
+	}
+
+	account, err := types.NewAccountFromKeystore(keystorePath, password)
+	if err != nil {
+		return nil, fmt.Errorf("load keystore %s failed: %w", keystorePath, err)
+	}
+	return account, nil
+}
+
+// parseAmount parses the amount of BNB in wei
+func parseAmount(s string) (math.Int, error) {
+	amount, ok := math.NewIntFromString(s)
+	if !ok || !amount.IsPositive() {
+		return math.Int{}, fmt.Errorf("invalid amount %s, it must be a positive integer in wei", s)
+	}
+	return amount, nil
+}
+
+// parseObjectPath splits the path like bucket/object
+func parseObjectPath(s string) (string, string, error) {
+	bucketName, objectName, found := strings.Cut(strings.TrimPrefix(s, "gnfd://"), "/")
+	if !found || bucketName == "" || objectName == "" {
+		return "", "", fmt.Errorf("invalid object path %s, it must be like bucket/object", s)
+	}
+	return bucketName, objectName, nil
+}
+
+// requireArgs checks the number of the positional arguments
+func requireArgs(c *cli.Context, n int) error {
+	if c.NArg() != n {
+		return fmt.Errorf("%s expects %d argument(s), usage: %s %s", c.Command.FullName(), n,
+			c.Command.FullName(), c.Command.ArgsUsage)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseObjectPath(t *testing.T) {
+	tests := []struct {
+		path       string
+		bucketName string
+		objectName string
+		wantErr    bool
+	}{
+		{path: "bucket/object", bucketName: "bucket", objectName: "object"},
+		{path: "gnfd://bucket/dir/object", bucketName: "bucket", objectName: "dir/object"},
+		{path: "bucket", wantErr: true},
+		{path: "bucket/", wantErr: true},
+		{path: "/object", wantErr: true},
+	}
+	for _, tt := range tests {
+		bucketName, objectName, err := parseObjectPath(tt.path)
+		if tt.wantErr {
+			assert.Error(t, err, tt.path)
+			continue
+		}
+		require.NoError(t, err, tt.path)
+		assert.Equal(t, tt.bucketName, bucketName)
+		assert.Equal(t, tt.objectName, objectName)
+	}
+}
+
+func TestParseActions(t *testing.T) {
+	actions, err := parseActions([]string{"get_object", "ACTION_DELETE_OBJECT"})
+	require.NoError(t, err)
+	assert.Equal(t, []permTypes.ActionType{permTypes.ACTION_GET_OBJECT, permTypes.ACTION_DELETE_OBJECT}, actions)
+
+	_, err = parseActions([]string{"fly"})
+	assert.Error(t, err)
+}
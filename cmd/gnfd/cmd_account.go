@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	"github.com/urfave/cli/v2"
+)
+
+func accountCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "account",
+		Usage: "show the balances of accounts and transfer BNB",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "balance",
+				Usage:     "show the balance of an account, the account of the keystore by default",
+				ArgsUsage: "[address]",
+				Action:    getBalance,
+			},
+			{
+				Name:      "transfer",
+				Usage:     "transfer BNB of the account to another account",
+				ArgsUsage: "<to-address> <amount>",
+				Action:    transfer,
+			},
+		},
+	}
+}
+
+func getBalance(c *cli.Context) error {
+	address := c.Args().First()
+	gnfdCli, err := newClient(c, address == "")
+	if err != nil {
+		return err
+	}
+	if address == "" {
+		account, err := gnfdCli.GetDefaultAccount()
+		if err != nil {
+			return err
+		}
+		address = account.GetAddress().String()
+	}
+
+	balance, err := gnfdCli.GetAccountBalance(c.Context, address)
+	if err != nil {
+		return err
+	}
+	result := struct {
+		Address string `json:"address"`
+		Denom   string `json:"denom"`
+		Amount  string `json:"amount"`
+	}{address, balance.Denom, balance.Amount.String()}
+	return printResult(c, result, func(w io.Writer) {
+		fmt.Fprintf(w, "%s\t%s %s\n", address, balance.Amount.String(), balance.Denom)
+	})
+}
+
+func transfer(c *cli.Context) error {
+	if err := requireArgs(c, 2); err != nil {
+		return err
+	}
+	amount, err := parseAmount(c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+	txHash, err := gnfdCli.Transfer(c.Context, c.Args().Get(0), amount, gnfdSdkTypes.TxOption{})
+	if err != nil {
+		return err
+	}
+	if _, err = gnfdCli.WaitForTxSuccess(c.Context, txHash); err != nil {
+		return err
+	}
+	return printTx(c, txHash)
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func bucketCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "bucket",
+		Usage: "create, list, delete and head buckets",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "create",
+				Usage:     "create a bucket",
+				ArgsUsage: "<bucket>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "primary-sp", Usage: "operator address of the primary SP, the first in-service SP by default"},
+					&cli.StringFlag{Name: "visibility", Value: "private", Usage: "visibility of the bucket: public-read, private or inherit"},
+					&cli.StringFlag{Name: "payment-address", Usage: "address of the payment account, the owner by default"},
+					&cli.Uint64Flag{Name: "charged-quota", Usage: "read quota of the bucket in bytes"},
+				},
+				Action: createBucket,
+			},
+			{
+				Name:   "ls",
+				Usage:  "list the buckets of the account",
+				Action: listBuckets,
+			},
+			{
+				Name:      "rm",
+				Usage:     "delete an empty bucket",
+				ArgsUsage: "<bucket>",
+				Action:    deleteBucket,
+			},
+			{
+				Name:      "head",
+				Usage:     "show the info of a bucket",
+				ArgsUsage: "<bucket>",
+				Action:    headBucket,
+			},
+		},
+	}
+}
+
+func createBucket(c *cli.Context) error {
+	if err := requireArgs(c, 1); err != nil {
+		return err
+	}
+	visibility, err := parseVisibility(c.String("visibility"))
+	if err != nil {
+		return err
+	}
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+
+	primarySP := c.String("primary-sp")
+	if primarySP == "" {
+		spList, err := gnfdCli.ListStorageProviders(c.Context, true)
+		if err != nil {
+			return err
+		}
+		if len(spList) == 0 {
+			return errors.New("no in-service SP found on chain")
+		}
+		primarySP = spList[0].OperatorAddress
+	}
+
+	txHash, err := gnfdCli.CreateBucket(c.Context, c.Args().First(), primarySP, types.CreateBucketOptions{
+		Visibility:     visibility,
+		PaymentAddress: c.String("payment-address"),
+		ChargedQuota:   c.Uint64("charged-quota"),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = gnfdCli.WaitForTxSuccess(c.Context, txHash); err != nil {
+		return err
+	}
+	return printTx(c, txHash)
+}
+
+func listBuckets(c *cli.Context) error {
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+	listResult, err := gnfdCli.ListBuckets(c.Context)
+	if err != nil {
+		return err
+	}
+
+	buckets := make([]*types.BucketInfo, 0, len(listResult.Buckets))
+	for _, bucketMeta := range listResult.Buckets {
+		if !bucketMeta.Removed && bucketMeta.BucketInfo != nil {
+			buckets = append(buckets, bucketMeta.BucketInfo)
+		}
+	}
+	return printResult(c, buckets, func(w io.Writer) {
+		fmt.Fprintln(w, "NAME\tID\tVISIBILITY\tCREATED")
+		for _, bucketInfo := range buckets {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", bucketInfo.BucketName, bucketInfo.Id.String(),
+				bucketInfo.Visibility.String(), formatTime(bucketInfo.CreateAt))
+		}
+	})
+}
+
+func deleteBucket(c *cli.Context) error {
+	if err := requireArgs(c, 1); err != nil {
+		return err
+	}
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+	txHash, err := gnfdCli.DeleteBucket(c.Context, c.Args().First(), types.DeleteBucketOption{})
+	if err != nil {
+		return err
+	}
+	if _, err = gnfdCli.WaitForTxSuccess(c.Context, txHash); err != nil {
+		return err
+	}
+	return printTx(c, txHash)
+}
+
+func headBucket(c *cli.Context) error {
+	if err := requireArgs(c, 1); err != nil {
+		return err
+	}
+	gnfdCli, err := newClient(c, false)
+	if err != nil {
+		return err
+	}
+	bucketInfo, err := gnfdCli.HeadBucket(c.Context, c.Args().First())
+	if err != nil {
+		return err
+	}
+	return printResult(c, bucketInfo, func(w io.Writer) {
+		fmt.Fprintf(w, "name:\t%s\n", bucketInfo.BucketName)
+		fmt.Fprintf(w, "id:\t%s\n", bucketInfo.Id.String())
+		fmt.Fprintf(w, "owner:\t%s\n", bucketInfo.Owner)
+		fmt.Fprintf(w, "visibility:\t%s\n", bucketInfo.Visibility.String())
+		fmt.Fprintf(w, "primary sp:\t%s\n", bucketInfo.PrimarySpAddress)
+		fmt.Fprintf(w, "payment address:\t%s\n", bucketInfo.PaymentAddress)
+		fmt.Fprintf(w, "charged quota:\t%d\n", bucketInfo.ChargedReadQuota)
+		fmt.Fprintf(w, "created:\t%s\n", formatTime(bucketInfo.CreateAt))
+	})
+}
+
+// parseVisibility parses the visibility names public-read, private and inherit
+func parseVisibility(s string) (storageTypes.VisibilityType, error) {
+	switch s {
+	case "public-read":
+		return storageTypes.VISIBILITY_TYPE_PUBLIC_READ, nil
+	case "private":
+		return storageTypes.VISIBILITY_TYPE_PRIVATE, nil
+	case "inherit":
+		return storageTypes.VISIBILITY_TYPE_INHERIT, nil
+	}
+	return storageTypes.VISIBILITY_TYPE_UNSPECIFIED, fmt.Errorf("invalid visibility %s, it must be public-read, private or inherit", s)
+}
+
+func formatTime(unix int64) string {
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func groupCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "group",
+		Usage: "create groups and manage the members",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "create",
+				Usage:     "create a group owned by the account",
+				ArgsUsage: "<group> [member...]",
+				Action:    createGroup,
+			},
+			{
+				Name:      "add",
+				Usage:     "add members to a group owned by the account",
+				ArgsUsage: "<group> <member...>",
+				Action: func(c *cli.Context) error {
+					return updateGroupMember(c, true)
+				},
+			},
+			{
+				Name:      "rm",
+				Usage:     "remove members from a group owned by the account",
+				ArgsUsage: "<group> <member...>",
+				Action: func(c *cli.Context) error {
+					return updateGroupMember(c, false)
+				},
+			},
+		},
+	}
+}
+
+func createGroup(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return requireArgs(c, 1)
+	}
+	members := make([]sdk.AccAddress, 0, c.NArg()-1)
+	for _, member := range c.Args().Tail() {
+		addr, err := sdk.AccAddressFromHexUnsafe(member)
+		if err != nil {
+			return err
+		}
+		members = append(members, addr)
+	}
+
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+	txHash, err := gnfdCli.CreateGroup(c.Context, c.Args().First(), types.CreateGroupOptions{InitGroupMember: members})
+	if err != nil {
+		return err
+	}
+	if _, err = gnfdCli.WaitForTxSuccess(c.Context, txHash); err != nil {
+		return err
+	}
+	return printTx(c, txHash)
+}
+
+func updateGroupMember(c *cli.Context, isAdd bool) error {
+	if c.NArg() < 2 {
+		return requireArgs(c, 2)
+	}
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+	account, err := gnfdCli.GetDefaultAccount()
+	if err != nil {
+		return err
+	}
+
+	var addMembers, removeMembers []string
+	if isAdd {
+		addMembers = c.Args().Tail()
+	} else {
+		removeMembers = c.Args().Tail()
+	}
+	txHash, err := gnfdCli.UpdateGroupMember(c.Context, c.Args().First(), account.GetAddress().String(),
+		addMembers, removeMembers, types.UpdateGroupMemberOption{})
+	if err != nil {
+		return err
+	}
+	if _, err = gnfdCli.WaitForTxSuccess(c.Context, txHash); err != nil {
+		return err
+	}
+	return printTx(c, txHash)
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func objectCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "object",
+		Usage: "upload, download, list, delete and head objects",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "put",
+				Usage:     "create an object and upload the payload of the local file",
+				ArgsUsage: "<file> <bucket/object>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "content-type", Usage: "content type of the object, detected by the file extension by default"},
+					&cli.StringFlag{Name: "visibility", Value: "inherit", Usage: "visibility of the object: public-read, private or inherit"},
+				},
+				Action: putObject,
+			},
+			{
+				Name:      "get",
+				Usage:     "download an object to the local file or stdout",
+				ArgsUsage: "<bucket/object> [file]",
+				Action:    getObject,
+			},
+			{
+				Name:      "ls",
+				Usage:     "list the objects of a bucket",
+				ArgsUsage: "<bucket>",
+				Action:    listObjects,
+			},
+			{
+				Name:      "rm",
+				Usage:     "delete an object",
+				ArgsUsage: "<bucket/object>",
+				Action:    deleteObject,
+			},
+			{
+				Name:      "head",
+				Usage:     "show the info of an object",
+				ArgsUsage: "<bucket/object>",
+				Action:    headObject,
+			},
+		},
+	}
+}
+
+func putObject(c *cli.Context) error {
+	if err := requireArgs(c, 2); err != nil {
+		return err
+	}
+	filePath := c.Args().Get(0)
+	bucketName, objectName, err := parseObjectPath(c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	visibility, err := parseVisibility(c.String("visibility"))
+	if err != nil {
+		return err
+	}
+	contentType := c.String("content-type")
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(filePath))
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+	txHash, err := gnfdCli.CreateObject(c.Context, bucketName, objectName, file, types.CreateObjectOptions{
+		Visibility:  visibility,
+		ContentType: contentType,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = gnfdCli.WaitForTxSuccess(c.Context, txHash); err != nil {
+		return err
+	}
+
+	// the empty object has no payload to upload
+	if stat.Size() > 0 {
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		err = gnfdCli.PutObject(c.Context, bucketName, objectName, stat.Size(), file,
+			types.PutObjectOptions{ContentType: contentType, TxnHash: txHash})
+		if err != nil {
+			return err
+		}
+	}
+	return printTx(c, txHash)
+}
+
+func getObject(c *cli.Context) error {
+	if c.NArg() != 1 && c.NArg() != 2 {
+		return requireArgs(c, 2)
+	}
+	bucketName, objectName, err := parseObjectPath(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+	body, stat, err := gnfdCli.GetObject(c.Context, bucketName, objectName, types.GetObjectOption{})
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// the payload is written to stdout if the file is not given
+	filePath := c.Args().Get(1)
+	if filePath == "" {
+		_, err = io.Copy(os.Stdout, body)
+		return err
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	size, err := io.Copy(file, body)
+	if err != nil {
+		return err
+	}
+
+	result := struct {
+		Bucket string `json:"bucket"`
+		Object string `json:"object"`
+		File   string `json:"file"`
+		Size   int64  `json:"size"`
+	}{bucketName, objectName, filePath, size}
+	return printResult(c, result, func(w io.Writer) {
+		fmt.Fprintf(w, "downloaded %s/%s to %s, %d bytes, content type %s\n", bucketName, objectName,
+			filePath, size, stat.ContentType)
+	})
+}
+
+func listObjects(c *cli.Context) error {
+	if err := requireArgs(c, 1); err != nil {
+		return err
+	}
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+	listResult, err := gnfdCli.ListObjects(c.Context, c.Args().First(), types.ListObjectsOptions{})
+	if err != nil {
+		return err
+	}
+
+	objects := make([]*types.ObjectInfo, 0, len(listResult.Objects))
+	for _, objectMeta := range listResult.Objects {
+		if !objectMeta.Removed && objectMeta.ObjectInfo != nil {
+			objects = append(objects, objectMeta.ObjectInfo)
+		}
+	}
+	return printResult(c, objects, func(w io.Writer) {
+		fmt.Fprintln(w, "NAME\tSIZE\tSTATUS\tCREATED")
+		for _, objectInfo := range objects {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", objectInfo.ObjectName, objectInfo.PayloadSize,
+				objectInfo.ObjectStatus.String(), formatTime(objectInfo.CreateAt))
+		}
+	})
+}
+
+func deleteObject(c *cli.Context) error {
+	if err := requireArgs(c, 1); err != nil {
+		return err
+	}
+	bucketName, objectName, err := parseObjectPath(c.Args().First())
+	if err != nil {
+		return err
+	}
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+	txHash, err := gnfdCli.DeleteObject(c.Context, bucketName, objectName, types.DeleteObjectOption{})
+	if err != nil {
+		return err
+	}
+	if _, err = gnfdCli.WaitForTxSuccess(c.Context, txHash); err != nil {
+		return err
+	}
+	return printTx(c, txHash)
+}
+
+func headObject(c *cli.Context) error {
+	if err := requireArgs(c, 1); err != nil {
+		return err
+	}
+	bucketName, objectName, err := parseObjectPath(c.Args().First())
+	if err != nil {
+		return err
+	}
+	gnfdCli, err := newClient(c, false)
+	if err != nil {
+		return err
+	}
+	objectInfo, err := gnfdCli.HeadObject(c.Context, bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	return printResult(c, objectInfo, func(w io.Writer) {
+		fmt.Fprintf(w, "name:\t%s\n", objectInfo.ObjectName)
+		fmt.Fprintf(w, "bucket:\t%s\n", objectInfo.BucketName)
+		fmt.Fprintf(w, "id:\t%s\n", objectInfo.Id.String())
+		fmt.Fprintf(w, "owner:\t%s\n", objectInfo.Owner)
+		fmt.Fprintf(w, "size:\t%d\n", objectInfo.PayloadSize)
+		fmt.Fprintf(w, "content type:\t%s\n", objectInfo.ContentType)
+		fmt.Fprintf(w, "visibility:\t%s\n", objectInfo.Visibility.String())
+		fmt.Fprintf(w, "status:\t%s\n", objectInfo.ObjectStatus.String())
+		fmt.Fprintf(w, "created:\t%s\n", formatTime(objectInfo.CreateAt))
+	})
+}
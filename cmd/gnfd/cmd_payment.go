@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	"github.com/urfave/cli/v2"
+)
+
+func paymentCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "payment",
+		Usage: "deposit to, withdraw from and show the stream records of payment accounts",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "deposit",
+				Usage:     "deposit BNB of the account to a payment account",
+				ArgsUsage: "<payment-account> <amount>",
+				Action:    deposit,
+			},
+			{
+				Name:      "withdraw",
+				Usage:     "withdraw BNB from a payment account owned by the account",
+				ArgsUsage: "<payment-account> <amount>",
+				Action:    withdraw,
+			},
+			{
+				Name:      "stream",
+				Usage:     "show the stream record of a payment account",
+				ArgsUsage: "<payment-account>",
+				Action:    getStreamRecord,
+			},
+		},
+	}
+}
+
+func deposit(c *cli.Context) error {
+	if err := requireArgs(c, 2); err != nil {
+		return err
+	}
+	amount, err := parseAmount(c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+	txHash, err := gnfdCli.Deposit(c.Context, c.Args().Get(0), amount, gnfdSdkTypes.TxOption{})
+	if err != nil {
+		return err
+	}
+	if _, err = gnfdCli.WaitForTxSuccess(c.Context, txHash); err != nil {
+		return err
+	}
+	return printTx(c, txHash)
+}
+
+func withdraw(c *cli.Context) error {
+	if err := requireArgs(c, 2); err != nil {
+		return err
+	}
+	amount, err := parseAmount(c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+	txHash, err := gnfdCli.Withdraw(c.Context, c.Args().Get(0), amount, gnfdSdkTypes.TxOption{})
+	if err != nil {
+		return err
+	}
+	if _, err = gnfdCli.WaitForTxSuccess(c.Context, txHash); err != nil {
+		return err
+	}
+	return printTx(c, txHash)
+}
+
+func getStreamRecord(c *cli.Context) error {
+	if err := requireArgs(c, 1); err != nil {
+		return err
+	}
+	gnfdCli, err := newClient(c, false)
+	if err != nil {
+		return err
+	}
+	streamRecord, err := gnfdCli.GetStreamRecord(c.Context, c.Args().First())
+	if err != nil {
+		return err
+	}
+	return printResult(c, streamRecord, func(w io.Writer) {
+		fmt.Fprintf(w, "account:\t%s\n", streamRecord.Account)
+		fmt.Fprintf(w, "status:\t%s\n", streamRecord.Status.String())
+		fmt.Fprintf(w, "static balance:\t%s\n", streamRecord.StaticBalance.String())
+		fmt.Fprintf(w, "buffer balance:\t%s\n", streamRecord.BufferBalance.String())
+		fmt.Fprintf(w, "lock balance:\t%s\n", streamRecord.LockBalance.String())
+		fmt.Fprintf(w, "netflow rate:\t%s\n", streamRecord.NetflowRate.String())
+		fmt.Fprintf(w, "settle time:\t%s\n", formatTime(streamRecord.SettleTimestamp))
+	})
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/urfave/cli/v2"
+
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+func policyCommand() *cli.Command {
+	resourceFlags := []cli.Flag{
+		&cli.StringFlag{Name: "bucket", Required: true, Usage: "bucket of the policy"},
+		&cli.StringFlag{Name: "object", Usage: "object of the policy, the policy is on the bucket if it is not set"},
+	}
+	principalFlag := &cli.StringFlag{Name: "principal", Usage: "address of the principal account"}
+
+	return &cli.Command{
+		Name:  "policy",
+		Usage: "put, get and delete the permission policies of buckets and objects",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "put",
+				Usage: "grant the actions on the bucket or object to an account or a group",
+				Flags: append(resourceFlags,
+					principalFlag,
+					&cli.Uint64Flag{Name: "group-id", Usage: "id of the principal group, used if --principal is not set"},
+					&cli.StringSliceFlag{Name: "actions", Required: true, Usage: "actions of the statement, e.g. get_object,delete_object"},
+					&cli.StringFlag{Name: "effect", Value: "allow", Usage: "effect of the statement: allow or deny"},
+					&cli.DurationFlag{Name: "expire", Usage: "validity of the policy, it never expires by default"},
+				),
+				Action: putPolicy,
+			},
+			{
+				Name:   "get",
+				Usage:  "show the policy of the bucket or object for an account",
+				Flags:  append(resourceFlags, principalFlag),
+				Action: getPolicy,
+			},
+			{
+				Name:   "rm",
+				Usage:  "delete the policy of the bucket or object for an account",
+				Flags:  append(resourceFlags, principalFlag),
+				Action: deletePolicy,
+			},
+		},
+	}
+}
+
+func putPolicy(c *cli.Context) error {
+	var (
+		principal types.Principal
+		err       error
+	)
+	switch {
+	case c.IsSet("principal"):
+		var addr sdk.AccAddress
+		addr, err = sdk.AccAddressFromHexUnsafe(c.String("principal"))
+		if err != nil {
+			return err
+		}
+		principal, err = utils.NewPrincipalWithAccount(addr)
+		if err != nil {
+			return err
+		}
+	case c.IsSet("group-id"):
+		principal, err = utils.NewPrincipalWithGroupId(c.Uint64("group-id"))
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.New("either --principal or --group-id must be set")
+	}
+
+	actions, err := parseActions(c.StringSlice("actions"))
+	if err != nil {
+		return err
+	}
+	var effect permTypes.Effect
+	switch c.String("effect") {
+	case "allow":
+		effect = permTypes.EFFECT_ALLOW
+	case "deny":
+		effect = permTypes.EFFECT_DENY
+	default:
+		return fmt.Errorf("invalid effect %s, it must be allow or deny", c.String("effect"))
+	}
+	statement := utils.NewStatement(actions, effect, nil, types.NewStatementOptions{})
+
+	opt := types.PutPolicyOption{}
+	if c.IsSet("expire") {
+		expireTime := time.Now().Add(c.Duration("expire"))
+		opt.PolicyExpireTime = &expireTime
+	}
+
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+	var txHash string
+	if objectName := c.String("object"); objectName != "" {
+		txHash, err = gnfdCli.PutObjectPolicy(c.Context, c.String("bucket"), objectName, principal,
+			[]*permTypes.Statement{&statement}, opt)
+	} else {
+		txHash, err = gnfdCli.PutBucketPolicy(c.Context, c.String("bucket"), principal,
+			[]*permTypes.Statement{&statement}, opt)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err = gnfdCli.WaitForTxSuccess(c.Context, txHash); err != nil {
+		return err
+	}
+	return printTx(c, txHash)
+}
+
+func getPolicy(c *cli.Context) error {
+	if !c.IsSet("principal") {
+		return errors.New("--principal must be set")
+	}
+	gnfdCli, err := newClient(c, false)
+	if err != nil {
+		return err
+	}
+
+	var policy *permTypes.Policy
+	if objectName := c.String("object"); objectName != "" {
+		policy, err = gnfdCli.GetObjectPolicy(c.Context, c.String("bucket"), objectName, c.String("principal"))
+	} else {
+		policy, err = gnfdCli.GetBucketPolicy(c.Context, c.String("bucket"), c.String("principal"))
+	}
+	if err != nil {
+		return err
+	}
+	return printResult(c, policy, func(w io.Writer) {
+		fmt.Fprintf(w, "id:\t%s\n", policy.Id.String())
+		fmt.Fprintf(w, "resource:\t%s\n", policy.ResourceId.String())
+		if policy.ExpirationTime != nil {
+			fmt.Fprintf(w, "expiration:\t%s\n", policy.ExpirationTime.UTC().Format(time.RFC3339))
+		}
+		for _, statement := range policy.Statements {
+			actions := make([]string, 0, len(statement.Actions))
+			for _, action := range statement.Actions {
+				actions = append(actions, action.String())
+			}
+			fmt.Fprintf(w, "statement:\t%s %s\n", statement.Effect.String(), strings.Join(actions, ","))
+		}
+	})
+}
+
+func deletePolicy(c *cli.Context) error {
+	if !c.IsSet("principal") {
+		return errors.New("--principal must be set")
+	}
+	gnfdCli, err := newClient(c, true)
+	if err != nil {
+		return err
+	}
+
+	var txHash string
+	if objectName := c.String("object"); objectName != "" {
+		txHash, err = gnfdCli.DeleteObjectPolicy(c.Context, c.String("bucket"), objectName, c.String("principal"),
+			types.DeletePolicyOption{})
+	} else {
+		txHash, err = gnfdCli.DeleteBucketPolicy(c.Context, c.String("bucket"), c.String("principal"),
+			types.DeletePolicyOption{})
+	}
+	if err != nil {
+		return err
+	}
+	if _, err = gnfdCli.WaitForTxSuccess(c.Context, txHash); err != nil {
+		return err
+	}
+	return printTx(c, txHash)
+}
+
+// parseActions parses the action names like get_object or ACTION_GET_OBJECT
+func parseActions(names []string) ([]permTypes.ActionType, error) {
+	actions := make([]permTypes.ActionType, 0, len(names))
+	for _, name := range names {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if !strings.HasPrefix(name, "ACTION_") {
+			name = "ACTION_" + name
+		}
+		value, ok := permTypes.ActionType_value[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid action %s", name)
+		}
+		actions = append(actions, permTypes.ActionType(value))
+	}
+	return actions, nil
+}
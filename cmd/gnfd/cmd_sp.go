@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v2"
+)
+
+func spCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "sp",
+		Usage: "list the storage providers and show their prices",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "ls",
+				Usage: "list the storage providers",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "all", Usage: "include the SPs which are not in service"},
+				},
+				Action: listSPs,
+			},
+			{
+				Name:      "price",
+				Usage:     "show the storage price of a storage provider",
+				ArgsUsage: "<sp-operator-address>",
+				Action:    getSPPrice,
+			},
+		},
+	}
+}
+
+func listSPs(c *cli.Context) error {
+	gnfdCli, err := newClient(c, false)
+	if err != nil {
+		return err
+	}
+	spList, err := gnfdCli.ListStorageProviders(c.Context, !c.Bool("all"))
+	if err != nil {
+		return err
+	}
+	return printResult(c, spList, func(w io.Writer) {
+		fmt.Fprintln(w, "OPERATOR\tENDPOINT\tSTATUS\tMONIKER")
+		for _, sp := range spList {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", sp.OperatorAddress, sp.Endpoint, sp.Status.String(),
+				sp.Description.Moniker)
+		}
+	})
+}
+
+func getSPPrice(c *cli.Context) error {
+	if err := requireArgs(c, 1); err != nil {
+		return err
+	}
+	gnfdCli, err := newClient(c, false)
+	if err != nil {
+		return err
+	}
+	price, err := gnfdCli.GetStoragePrice(c.Context, c.Args().First())
+	if err != nil {
+		return err
+	}
+	return printResult(c, price, func(w io.Writer) {
+		fmt.Fprintf(w, "sp:\t%s\n", price.SpAddress)
+		fmt.Fprintf(w, "store price:\t%s\n", price.StorePrice.String())
+		fmt.Fprintf(w, "read price:\t%s\n", price.ReadPrice.String())
+		fmt.Fprintf(w, "free read quota:\t%d\n", price.FreeReadQuota)
+		fmt.Fprintf(w, "updated:\t%s\n", formatTime(price.UpdateTimeSec))
+	})
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml"
+)
+
+// Config defines the configuration of the gnfd command line tool
+type Config struct {
+	// ChainID is the chain id of greenfield
+	ChainID string `toml:"ChainID"`
+	// RpcAddr is the tendermint rpc address of the greenfield node, e.g. http://localhost:26750
+	RpcAddr string `toml:"RpcAddr"`
+	// Secure specifies whether the SP endpoints are requested with https
+	Secure bool `toml:"Secure"`
+	// Host overrides the host of the requests sent to SP
+	Host string `toml:"Host"`
+	// Keystore is the path of the encrypted keystore file of the account
+	Keystore string `toml:"Keystore"`
+	// PasswordFile is the path of the file containing the keystore password
+	PasswordFile string `toml:"PasswordFile"`
+	// FeeGranter is the address of the account which pays the tx fees by its fee allowance
+	FeeGranter string `toml:"FeeGranter"`
+}
+
+func defaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "config.toml"
+	}
+	return filepath.Join(home, ".gnfd", "config.toml")
+}
+
+// LoadConfig reads the config from the toml file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file %s failed: %w", path, err)
+	}
+
+	cfg := &Config{}
+	if err = toml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse config file %s failed: %w", path, err)
+	}
+	if cfg.ChainID == "" || cfg.RpcAddr == "" {
+		return nil, errors.New("ChainID and RpcAddr must be set in the config file")
+	}
+	return cfg, nil
+}
@@ -0,0 +1,65 @@
+// gnfd is the command line tool of greenfield built on the SDK, e.g.
+//
+//	gnfd bucket create my-bucket
+//	gnfd object put ./file.txt my-bucket/file.txt
+//	gnfd --json object ls my-bucket
+//
+// The chain endpoint and the keystore of the account are read from the config file,
+// $HOME/.gnfd/config.toml by default, see Config for the fields.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagConfig       = "config"
+	flagKeystore     = "keystore"
+	flagPasswordFile = "password-file"
+	flagJSON         = "json"
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "gnfd",
+		Usage: "command line tool of greenfield",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    flagConfig,
+				Aliases: []string{"c"},
+				Usage:   "path of the config file",
+				Value:   defaultConfigPath(),
+			},
+			&cli.StringFlag{
+				Name:    flagKeystore,
+				Aliases: []string{"k"},
+				Usage:   "path of the keystore file, overrides the keystore of the config file",
+			},
+			&cli.StringFlag{
+				Name:  flagPasswordFile,
+				Usage: "path of the file containing the keystore password, the password is prompted if not set",
+			},
+			&cli.BoolFlag{
+				Name:  flagJSON,
+				Usage: "print the results as JSON",
+			},
+		},
+		Commands: []*cli.Command{
+			bucketCommand(),
+			objectCommand(),
+			groupCommand(),
+			policyCommand(),
+			paymentCommand(),
+			accountCommand(),
+			spCommand(),
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+)
+
+// printResult prints v as JSON if --json is set, otherwise the text written by printText
+func printResult(c *cli.Context, v interface{}, printText func(w io.Writer)) error {
+	if c.Bool(flagJSON) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	printText(w)
+	return w.Flush()
+}
+
+// txResult is the result of the commands sending a txn
+type txResult struct {
+	TxHash string `json:"tx_hash"`
+}
+
+func printTx(c *cli.Context, txHash string) error {
+	return printResult(c, txResult{TxHash: txHash}, func(w io.Writer) {
+		fmt.Fprintf(w, "transaction %s committed\n", txHash)
+	})
+}
@@ -59,9 +59,14 @@ func (s *BaseSuite) SetupSuite() {
 	account, err := types.NewAccountFromMnemonic("test", mnemonic)
 	s.Require().NoError(err)
 	s.Client, err = client.New(ChainID, Endpoint, client.Option{
-		DefaultAccount: account,
+		DefaultAccount:  account,
+		EnableWebsocket: true,
 	})
 	s.Require().NoError(err)
 	s.ClientContext = context.Background()
 	s.DefaultAccount = account
 }
+
+func (s *BaseSuite) TearDownSuite() {
+	s.Require().NoError(s.Client.Close())
+}
@@ -3,12 +3,20 @@ package e2e
 import (
 	"fmt"
 	"github.com/stretchr/testify/suite"
+	"sync"
 	"testing"
 
 	"cosmossdk.io/math"
+	"github.com/bnb-chain/greenfield-go-sdk/client"
 	"github.com/bnb-chain/greenfield-go-sdk/e2e/basesuite"
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
 	"github.com/bnb-chain/greenfield-go-sdk/types"
 	types2 "github.com/bnb-chain/greenfield/sdk/types"
+	storageTestUtil "github.com/bnb-chain/greenfield/testutil/storage"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 )
 
 type BasicTestSuite struct {
@@ -81,6 +89,288 @@ func (s *BasicTestSuite) Test_Account() {
 	s.Require().Equal(len(paymentAccountsByOwner), 1)
 }
 
+func (s *BasicTestSuite) Test_OfflineTransfer() {
+	receiver, _, err := types.NewAccount("receiver")
+	s.Require().NoError(err)
+
+	// the online side builds the tx with the watch-only account
+	watchOnly, err := types.NewAccountFromPubKey("watch-only", s.DefaultAccount.PubKey())
+	s.Require().NoError(err)
+	unsignedTx := &types.UnsignedTx{}
+	ctx := client.WithGenerateOnly(client.WithAccount(s.ClientContext, watchOnly), unsignedTx)
+	txHash, err := s.Client.Transfer(ctx, receiver.GetAddress().String(), math.NewInt(100), types2.TxOption{})
+	s.Require().NoError(err)
+	s.Require().Empty(txHash)
+	s.Require().NotEmpty(unsignedTx.Tx)
+
+	// the offline side signs it
+	txBytes, err := types.SignTx(unsignedTx, s.DefaultAccount)
+	s.Require().NoError(err)
+
+	txResp, err := s.Client.BroadcastRawTx(s.ClientContext, txBytes, true)
+	s.Require().NoError(err)
+	s.Require().Equal(uint32(0), txResp.Code)
+	_, err = s.Client.WaitForTx(s.ClientContext, txResp.TxHash)
+	s.Require().NoError(err)
+
+	balance, err := s.Client.GetAccountBalance(s.ClientContext, receiver.GetAddress().String())
+	s.Require().NoError(err)
+	s.Require().True(balance.Amount.Equal(math.NewInt(100)))
+}
+
+func (s *BasicTestSuite) Test_GasPolicy() {
+	receiver, _, err := types.NewAccount("receiver")
+	s.Require().NoError(err)
+	msgs := []sdk.Msg{bankTypes.NewMsgSend(s.DefaultAccount.GetAddress(), receiver.GetAddress(),
+		sdk.NewCoins(sdk.NewCoin(types2.Denom, math.NewInt(100))))}
+
+	estimate, err := s.Client.EstimateFee(s.ClientContext, msgs, types2.TxOption{})
+	s.Require().NoError(err)
+	s.Require().Equal(estimate.GasUsed, estimate.GasLimit)
+	s.Require().False(estimate.Fee.IsZero())
+
+	ctx := client.WithGasPolicy(s.ClientContext, &types.GasPolicy{GasMultiplier: 1.5})
+	withMultiplier, err := s.Client.EstimateFee(ctx, msgs, types2.TxOption{})
+	s.Require().NoError(err)
+	s.Require().Greater(withMultiplier.GasLimit, withMultiplier.GasUsed)
+
+	txHash, err := s.Client.Transfer(ctx, receiver.GetAddress().String(), math.NewInt(100), types2.TxOption{})
+	s.Require().NoError(err)
+	txResp, err := s.Client.WaitForTx(s.ClientContext, txHash)
+	s.Require().NoError(err)
+	s.Require().Equal(uint32(0), txResp.Code)
+	s.Require().Greater(txResp.GasWanted, txResp.GasUsed)
+}
+
+func (s *BasicTestSuite) Test_DryRun() {
+	receiver, _, err := types.NewAccount("receiver")
+	s.Require().NoError(err)
+
+	result := &types.DryRunResult{}
+	ctx := client.WithDryRun(s.ClientContext, result)
+	txHash, err := s.Client.Transfer(ctx, receiver.GetAddress().String(), math.NewInt(100), types2.TxOption{})
+	s.Require().NoError(err)
+	s.Require().Empty(txHash)
+	s.Require().Len(result.Msgs, 1)
+	s.Require().NotZero(result.GasEstimate.GasLimit)
+	s.Require().False(result.GasEstimate.Fee.IsZero())
+	s.Require().NotEmpty(result.Events)
+
+	// nothing is sent to chain
+	balance, err := s.Client.GetAccountBalance(s.ClientContext, receiver.GetAddress().String())
+	s.Require().NoError(err)
+	s.Require().True(balance.Amount.IsZero())
+
+	// the error of the chain is returned without broadcasting
+	_, err = s.Client.DeleteBucket(client.WithDryRun(s.ClientContext, &types.DryRunResult{}),
+		storageTestUtil.GenRandomBucketName(), types.DeleteBucketOption{})
+	s.Require().Error(err)
+}
+
+func (s *BasicTestSuite) Test_SearchTxs() {
+	receiver, _, err := types.NewAccount("receiver")
+	s.Require().NoError(err)
+	txHash, err := s.Client.Transfer(s.ClientContext, receiver.GetAddress().String(), math.NewInt(100), types2.TxOption{Memo: "search"})
+	s.Require().NoError(err)
+	txResp, err := s.Client.WaitForTx(s.ClientContext, txHash)
+	s.Require().NoError(err)
+
+	query := types.TxQuery{
+		Sender:     s.DefaultAccount.GetAddress().String(),
+		MsgType:    sdk.MsgTypeURL(&bankTypes.MsgSend{}),
+		MinHeight:  txResp.Height,
+		MaxHeight:  txResp.Height,
+		Descending: true,
+	}
+	txResults, total, err := s.Client.SearchTxs(s.ClientContext, query)
+	s.Require().NoError(err)
+	s.Require().GreaterOrEqual(total, 1)
+	var found *types.TxResult
+	for _, txResult := range txResults {
+		if txResult.TxHash == txHash {
+			found = txResult
+		}
+	}
+	s.Require().NotNil(found)
+	s.Require().Equal("search", found.Memo)
+	s.Require().Len(found.Msgs, 1)
+	msgSend, ok := found.Msgs[0].(*bankTypes.MsgSend)
+	s.Require().True(ok)
+	s.Require().Equal(receiver.GetAddress().String(), msgSend.ToAddress)
+
+	// the txs of the receiver are not sent by the default account
+	query.Sender = receiver.GetAddress().String()
+	txResults, total, err = s.Client.SearchTxs(s.ClientContext, query)
+	s.Require().NoError(err)
+	s.Require().Zero(total)
+	s.Require().Empty(txResults)
+}
+
+func (s *BasicTestSuite) Test_DecodeBlock() {
+	receiver, _, err := types.NewAccount("receiver")
+	s.Require().NoError(err)
+	txHash, err := s.Client.Transfer(s.ClientContext, receiver.GetAddress().String(), math.NewInt(100), types2.TxOption{Memo: "decode"})
+	s.Require().NoError(err)
+	txResp, err := s.Client.WaitForTx(s.ClientContext, txHash)
+	s.Require().NoError(err)
+
+	block, err := s.Client.GetBlockByHeight(s.ClientContext, txResp.Height)
+	s.Require().NoError(err)
+	decodedBlock, err := types.DecodeBlock(block)
+	s.Require().NoError(err)
+	s.Require().Equal(txResp.Height, decodedBlock.Height)
+	var found *types.DecodedTx
+	for _, decodedTx := range decodedBlock.Txs {
+		if decodedTx.Hash == txHash {
+			found = decodedTx
+		}
+	}
+	s.Require().NotNil(found)
+	s.Require().Equal("decode", found.Memo)
+	s.Require().Equal([]sdk.AccAddress{s.DefaultAccount.GetAddress()}, found.Signers)
+	s.Require().False(found.Fee.IsZero())
+	s.Require().Len(found.Msgs, 1)
+	_, ok := found.Msgs[0].(*bankTypes.MsgSend)
+	s.Require().True(ok)
+}
+
+func (s *BasicTestSuite) Test_Authz() {
+	grantee, _, err := types.NewAccount("grantee")
+	s.Require().NoError(err)
+	receiver, _, err := types.NewAccount("receiver")
+	s.Require().NoError(err)
+	// the grantee pays the gas of the MsgExec
+	txHash, err := s.Client.Transfer(s.ClientContext, grantee.GetAddress().String(), math.NewIntWithDecimal(1, types2.DecimalBNB), types2.TxOption{})
+	s.Require().NoError(err)
+	_, err = s.Client.WaitForTx(s.ClientContext, txHash)
+	s.Require().NoError(err)
+
+	msgTypeURL := sdk.MsgTypeURL(&bankTypes.MsgSend{})
+	txHash, err = s.Client.GrantAuthorization(s.ClientContext, grantee.GetAddress().String(),
+		authz.NewGenericAuthorization(msgTypeURL), types.GrantAuthorizationOptions{})
+	s.Require().NoError(err)
+	_, err = s.Client.WaitForTx(s.ClientContext, txHash)
+	s.Require().NoError(err)
+	grants, err := s.Client.QueryGrants(s.ClientContext, s.DefaultAccount.GetAddress().String(), grantee.GetAddress().String(), msgTypeURL)
+	s.Require().NoError(err)
+	s.Require().Len(grants, 1)
+
+	// the grantee transfers the tokens of the default account
+	ctx := client.WithExecAs(client.WithAccount(s.ClientContext, grantee), s.DefaultAccount.GetAddress())
+	txHash, err = s.Client.Transfer(ctx, receiver.GetAddress().String(), math.NewInt(100), types2.TxOption{})
+	s.Require().NoError(err)
+	_, err = s.Client.WaitForTx(s.ClientContext, txHash)
+	s.Require().NoError(err)
+	balance, err := s.Client.GetAccountBalance(s.ClientContext, receiver.GetAddress().String())
+	s.Require().NoError(err)
+	s.Require().True(balance.Amount.Equal(math.NewInt(100)))
+
+	txHash, err = s.Client.RevokeAuthorization(s.ClientContext, grantee.GetAddress().String(), msgTypeURL, types2.TxOption{})
+	s.Require().NoError(err)
+	_, err = s.Client.WaitForTx(s.ClientContext, txHash)
+	s.Require().NoError(err)
+	grants, err = s.Client.QueryGrants(s.ClientContext, "", grantee.GetAddress().String(), "")
+	s.Require().NoError(err)
+	s.Require().Len(grants, 0)
+}
+
+func (s *BasicTestSuite) Test_FeeGrant() {
+	grantee, _, err := types.NewAccount("grantee")
+	s.Require().NoError(err)
+	// the grantee holds no more than the 1 wei to get its account created on chain
+	txHash, err := s.Client.Transfer(s.ClientContext, grantee.GetAddress().String(), math.NewInt(1), types2.TxOption{})
+	s.Require().NoError(err)
+	_, err = s.Client.WaitForTx(s.ClientContext, txHash)
+	s.Require().NoError(err)
+
+	spendLimit := math.NewIntWithDecimal(1, types2.DecimalBNB)
+	txHash, err = s.Client.GrantAllowance(s.ClientContext, grantee.GetAddress().String(),
+		utils.NewBasicAllowance(&spendLimit, nil), types2.TxOption{})
+	s.Require().NoError(err)
+	_, err = s.Client.WaitForTx(s.ClientContext, txHash)
+	s.Require().NoError(err)
+	allowances, err := s.Client.QueryAllowances(s.ClientContext, grantee.GetAddress().String())
+	s.Require().NoError(err)
+	s.Require().Len(allowances, 1)
+
+	ctx := client.WithAccount(s.ClientContext, grantee)
+	txHash, err = s.Client.CreatePaymentAccount(ctx, grantee.GetAddress().String(), types2.TxOption{FeeGranter: s.DefaultAccount.GetAddress()})
+	s.Require().NoError(err)
+	_, err = s.Client.WaitForTx(s.ClientContext, txHash)
+	s.Require().NoError(err)
+	balance, err := s.Client.GetAccountBalance(s.ClientContext, grantee.GetAddress().String())
+	s.Require().NoError(err)
+	s.Require().True(balance.Amount.Equal(math.NewInt(1)))
+
+	txHash, err = s.Client.RevokeAllowance(s.ClientContext, grantee.GetAddress().String(), types2.TxOption{})
+	s.Require().NoError(err)
+	_, err = s.Client.WaitForTx(s.ClientContext, txHash)
+	s.Require().NoError(err)
+	_, err = s.Client.QueryAllowance(s.ClientContext, s.DefaultAccount.GetAddress().String(), grantee.GetAddress().String())
+	s.Require().Error(err)
+}
+
+func (s *BasicTestSuite) Test_ConcurrentTransfer() {
+	receiver, _, err := types.NewAccount("receiver")
+	s.Require().NoError(err)
+
+	// the txs of one account are sent concurrently with the sequences handed out by the client
+	txHashes := make([]string, 10)
+	var wg sync.WaitGroup
+	for i := range txHashes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			txHash, err := s.Client.Transfer(s.ClientContext, receiver.GetAddress().String(), math.NewInt(100), types2.TxOption{})
+			s.Require().NoError(err)
+			txHashes[i] = txHash
+		}(i)
+	}
+	wg.Wait()
+	for _, txHash := range txHashes {
+		_, err = s.Client.WaitForTx(s.ClientContext, txHash)
+		s.Require().NoError(err)
+	}
+
+	balance, err := s.Client.GetAccountBalance(s.ClientContext, receiver.GetAddress().String())
+	s.Require().NoError(err)
+	s.Require().True(balance.Amount.Equal(math.NewInt(1000)))
+}
+
+func (s *BasicTestSuite) Test_TxBatch() {
+	receivers := make([]*types.Account, 3)
+	batch := s.Client.NewTxBatch()
+	for i := range receivers {
+		receiver, _, err := types.NewAccount("receiver")
+		s.Require().NoError(err)
+		receivers[i] = receiver
+		s.Require().NoError(batch.Transfer(s.ClientContext, receiver.GetAddress().String(), math.NewInt(100)))
+	}
+	groupName := storageTestUtil.GenRandomGroupName()
+	s.Require().NoError(batch.CreateGroup(s.ClientContext, groupName, types.CreateGroupOptions{}))
+	// the members are added to the group created by the same batch
+	s.Require().NoError(batch.UpdateGroupMember(s.ClientContext, groupName, s.DefaultAccount.GetAddress().String(),
+		[]string{receivers[0].GetAddress().String()}, nil, types.UpdateGroupMemberOption{}))
+
+	results, err := batch.Send(s.ClientContext, types.TxBatchOption{MaxMsgsPerTx: 2})
+	s.Require().NoError(err)
+	s.Require().Len(results, 5)
+	s.Require().Equal(0, batch.Len())
+	s.Require().NotEqual(results[0].TxHash, results[4].TxHash)
+	for _, result := range results {
+		s.Require().NotEmpty(result.Events)
+	}
+	createGroupResp, ok := results[3].Response.(*storageTypes.MsgCreateGroupResponse)
+	s.Require().True(ok)
+	s.Require().False(createGroupResp.GroupId.IsZero())
+
+	for _, receiver := range receivers {
+		balance, err := s.Client.GetAccountBalance(s.ClientContext, receiver.GetAddress().String())
+		s.Require().NoError(err)
+		s.Require().True(balance.Amount.Equal(math.NewInt(100)))
+	}
+}
+
 func (s *BasicTestSuite) Test_MultiTransfer() {
 
 	transferDetails := make([]types.TransferDetail, 0)
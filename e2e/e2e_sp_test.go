@@ -117,6 +117,8 @@ func (s *SPTestSuite) Test_CreateStorageProvider() {
 		time.Sleep(1 * time.Second)
 	}
 
+	// the new SP is served by the client once the SP endpoints are refreshed
+	s.Require().NoError(s.Client.RefreshStorageProviders(s.ClientContext))
 }
 
 func TestSPTestSuite(t *testing.T) {
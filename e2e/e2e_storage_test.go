@@ -2,12 +2,14 @@ package e2e
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"testing"
 	"time"
 
 	"cosmossdk.io/math"
+	"github.com/bnb-chain/greenfield-go-sdk/client"
 	"github.com/bnb-chain/greenfield-go-sdk/e2e/basesuite"
 	"github.com/bnb-chain/greenfield-go-sdk/pkg/utils"
 	"github.com/bnb-chain/greenfield-go-sdk/types"
@@ -16,6 +18,7 @@ import (
 	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
 	spTypes "github.com/bnb-chain/greenfield/x/sp/types"
 	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -223,19 +226,91 @@ func (s *StorageTestSuite) Test_Object() {
 	s.Require().Error(err)
 }
 
+func (s *StorageTestSuite) Test_AutoPrimarySP() {
+	candidates, err := s.Client.SelectStorageProviders(s.ClientContext, types.SPSelectOption{})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(candidates)
+	for _, candidate := range candidates {
+		s.Require().True(candidate.Healthy)
+		// the SP registered by the SP test suite is not served
+		s.Require().NotEqual("https://sp0.greenfield.io", candidate.Info.Endpoint)
+	}
+
+	bucketName := storageTestUtil.GenRandomBucketName()
+	bucketTx, err := s.Client.CreateBucket(s.ClientContext, bucketName, types.AutoPrimarySP, types.CreateBucketOptions{})
+	s.Require().NoError(err)
+	_, err = s.Client.WaitForTx(s.ClientContext, bucketTx)
+	s.Require().NoError(err)
+
+	bucketInfo, err := s.Client.HeadBucket(s.ClientContext, bucketName)
+	s.Require().NoError(err)
+	selected := false
+	for _, candidate := range candidates {
+		selected = selected || candidate.Info.GetOperator().String() == bucketInfo.PrimarySpAddress
+	}
+	s.Require().True(selected)
+}
+
+func (s *StorageTestSuite) Test_Subscribe() {
+	ctx, cancel := context.WithTimeout(s.ClientContext, time.Minute)
+	defer cancel()
+	events, err := s.Client.Subscribe(ctx, types.EventFilter{
+		EventTypes: []string{proto.MessageName(&storageTypes.EventCreateGroup{})},
+	})
+	s.Require().NoError(err)
+
+	groupName := storageTestUtil.GenRandomGroupName()
+	txResult := &types.TxResult{}
+	_, err = s.Client.CreateGroup(client.WithTxResult(s.ClientContext, txResult), groupName, types.CreateGroupOptions{})
+	s.Require().NoError(err)
+
+	var received *types.ChainEvent
+	for event := range events {
+		s.Require().NoError(event.Err)
+		if e := event.Event.(*storageTypes.EventCreateGroup); e.GroupName == groupName {
+			received = event
+			break
+		}
+	}
+	s.Require().NotNil(received)
+	s.Require().Equal(txResult.TxHash, received.TxHash)
+	s.Require().Equal(txResult.Height, received.Height)
+
+	// the events of the committed blocks are replayed from the height
+	replayed, err := s.Client.Subscribe(ctx, types.EventFilter{
+		EventTypes: []string{proto.MessageName(&storageTypes.EventCreateGroup{})},
+		FromHeight: txResult.Height,
+	})
+	s.Require().NoError(err)
+	event := <-replayed
+	s.Require().NotNil(event)
+	s.Require().NoError(event.Err)
+	s.Require().Equal(groupName, event.Event.(*storageTypes.EventCreateGroup).GroupName)
+}
+
 func (s *StorageTestSuite) Test_Group() {
 	groupName := storageTestUtil.GenRandomGroupName()
 
 	groupOwner := s.DefaultAccount.GetAddress()
 	s.T().Log("---> CreateGroup and HeadGroup <---")
-	_, err := s.Client.CreateGroup(s.ClientContext, groupName, types.CreateGroupOptions{})
+	txResult := &types.TxResult{}
+	_, err := s.Client.CreateGroup(client.WithTxResult(s.ClientContext, txResult), groupName, types.CreateGroupOptions{})
 	s.Require().NoError(err)
 	s.T().Logf("create GroupName: %s", groupName)
+	s.Require().NotZero(txResult.Height)
+	s.Require().False(txResult.Fee.IsZero())
+	var createGroupEvent *storageTypes.EventCreateGroup
+	for _, event := range txResult.Events {
+		if e, ok := event.(*storageTypes.EventCreateGroup); ok {
+			createGroupEvent = e
+		}
+	}
+	s.Require().NotNil(createGroupEvent)
 
-	time.Sleep(5 * time.Second)
 	headResult, err := s.Client.HeadGroup(s.ClientContext, groupName, groupOwner.String())
 	s.Require().NoError(err)
 	s.Require().Equal(groupName, headResult.GroupName)
+	s.Require().Equal(createGroupEvent.GroupId, headResult.Id)
 
 	s.T().Log("---> Update GroupMember <---")
 	addAccount, _, err := types.NewAccount("member1")
@@ -0,0 +1,240 @@
+package davfs
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"time"
+
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/objectutil"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// fileInfo implements os.FileInfo, and webdav.ContentTyper and webdav.ETager for objects,
+// so that PROPFIND does not need to download the objects
+type fileInfo struct {
+	name        string
+	size        int64
+	modTime     time.Time
+	isDir       bool
+	contentType string
+	etag        string
+}
+
+func newObjectFileInfo(name string, size uint64, createAt int64, contentType string, checksums [][]byte) *fileInfo {
+	fi := &fileInfo{
+		name:        name,
+		size:        int64(size),
+		modTime:     time.Unix(createAt, 0),
+		contentType: contentType,
+	}
+	// the integrity hash of the primary SP identifies the payload of the object
+	if len(checksums) > 0 {
+		fi.etag = `"` + hex.EncodeToString(checksums[0]) + `"`
+	}
+	return fi
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *fileInfo) IsDir() bool        { return fi.isDir }
+func (fi *fileInfo) Sys() interface{}   { return nil }
+
+func (fi *fileInfo) Mode() os.FileMode {
+	if fi.isDir {
+		return os.ModeDir | 0o755
+	}
+	return 0o644
+}
+
+// ContentType implements webdav.ContentTyper
+func (fi *fileInfo) ContentType(ctx context.Context) (string, error) {
+	if fi.contentType != "" {
+		return fi.contentType, nil
+	}
+	if contentType := mime.TypeByExtension(path.Ext(fi.name)); contentType != "" {
+		return contentType, nil
+	}
+	return types.ContentDefault, nil
+}
+
+// ETag implements webdav.ETager
+func (fi *fileInfo) ETag(ctx context.Context) (string, error) {
+	if fi.etag == "" {
+		return `""`, nil
+	}
+	return fi.etag, nil
+}
+
+// dirFile is a directory opened for reading, the entries are listed on the first Readdir
+type dirFile struct {
+	fs         *FileSystem
+	ctx        context.Context
+	bucketName string
+	objectName string
+	info       os.FileInfo
+
+	entries []os.FileInfo
+	listed  bool
+	pos     int
+}
+
+func (f *dirFile) Readdir(count int) ([]os.FileInfo, error) {
+	if !f.listed {
+		entries, err := f.fs.readDir(f.ctx, f.bucketName, f.objectName)
+		if err != nil {
+			return nil, err
+		}
+		f.entries = entries
+		f.listed = true
+	}
+
+	rest := f.entries[f.pos:]
+	if count <= 0 {
+		f.pos = len(f.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(rest) {
+		count = len(rest)
+	}
+	f.pos += count
+	return rest[:count], nil
+}
+
+func (f *dirFile) Stat() (os.FileInfo, error)                   { return f.info, nil }
+func (f *dirFile) Read(p []byte) (int, error)                   { return 0, errIsDirectory }
+func (f *dirFile) Write(p []byte) (int, error)                  { return 0, errIsDirectory }
+func (f *dirFile) Seek(offset int64, whence int) (int64, error) { return 0, errIsDirectory }
+func (f *dirFile) Close() error                                 { return nil }
+
+// readFile is an object opened for reading, the payload is downloaded from the offset when it
+// is read, seeking to another offset starts a new download with range
+type readFile struct {
+	fs         *FileSystem
+	ctx        context.Context
+	bucketName string
+	objectName string
+	info       *fileInfo
+
+	offset int64
+	body   io.ReadCloser
+}
+
+func (f *readFile) Read(p []byte) (int, error) {
+	if f.offset >= f.info.size {
+		return 0, io.EOF
+	}
+
+	if f.body == nil {
+		opts := types.GetObjectOption{}
+		if f.offset > 0 {
+			if err := opts.SetRange(f.offset, f.info.size-1); err != nil {
+				return 0, err
+			}
+		}
+		body, _, err := f.fs.client.GetObject(f.ctx, f.bucketName, f.objectName, opts)
+		if err != nil {
+			return 0, err
+		}
+		f.body = body
+	}
+
+	n, err := f.body.Read(p)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *readFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.info.size
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if offset != f.offset && f.body != nil {
+		f.body.Close()
+		f.body = nil
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *readFile) Close() error {
+	if f.body != nil {
+		return f.body.Close()
+	}
+	return nil
+}
+
+func (f *readFile) Readdir(count int) ([]os.FileInfo, error) { return nil, errNotDirectory }
+func (f *readFile) Stat() (os.FileInfo, error)               { return f.info, nil }
+func (f *readFile) Write(p []byte) (int, error)              { return 0, os.ErrPermission }
+
+// writeFile is an object opened for writing, the payload is spooled to a temp file and uploaded
+// when the file is closed, since the integrity hash needs the whole payload
+type writeFile struct {
+	fs         *FileSystem
+	ctx        context.Context
+	bucketName string
+	objectName string
+	tmpFile    *os.File
+}
+
+func (fs *FileSystem) newWriteFile(ctx context.Context, bucketName, objectName string) (*writeFile, error) {
+	tmpFile, err := os.CreateTemp(fs.tempDir, "gnfd-davfs-*")
+	if err != nil {
+		return nil, err
+	}
+	return &writeFile{fs: fs, ctx: ctx, bucketName: bucketName, objectName: objectName, tmpFile: tmpFile}, nil
+}
+
+func (f *writeFile) Write(p []byte) (int, error) {
+	return f.tmpFile.Write(p)
+}
+
+func (f *writeFile) Seek(offset int64, whence int) (int64, error) {
+	return f.tmpFile.Seek(offset, whence)
+}
+
+func (f *writeFile) Stat() (os.FileInfo, error) {
+	tmpInfo, err := f.tmpFile.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return &fileInfo{name: path.Base(f.objectName), size: tmpInfo.Size(), modTime: tmpInfo.ModTime()}, nil
+}
+
+func (f *writeFile) Read(p []byte) (int, error)               { return 0, os.ErrPermission }
+func (f *writeFile) Readdir(count int) ([]os.FileInfo, error) { return nil, errNotDirectory }
+
+// Close uploads the payload. Objects on greenfield can not be overwritten, so an existing object
+// is replaced through a staging object, see objectutil.Put.
+func (f *writeFile) Close() error {
+	defer func() {
+		f.tmpFile.Close()
+		os.Remove(f.tmpFile.Name())
+	}()
+
+	tmpInfo, err := f.tmpFile.Stat()
+	if err != nil {
+		return err
+	}
+	err = objectutil.Put(f.ctx, f.fs.client, f.bucketName, f.objectName, f.tmpFile, tmpInfo.Size(),
+		types.CreateObjectOptions{ContentType: mime.TypeByExtension(path.Ext(f.objectName))})
+	if err != nil {
+		return convertErr(err)
+	}
+	return nil
+}
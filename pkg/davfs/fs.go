@@ -0,0 +1,296 @@
+// Package davfs implements the webdav.FileSystem of golang.org/x/net/webdav on top of greenfield.
+//
+// The root directory lists the buckets of the account, the buckets are the directories of the
+// second level and the objects are mapped to the paths under their bucket. A directory inside a
+// bucket is either a folder object ending with a forward slash (/) or the common prefix of objects.
+package davfs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"golang.org/x/net/webdav"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/objectutil"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+var (
+	errNotSupported = errors.New("operation not supported by greenfield")
+	errIsDirectory  = errors.New("is a directory")
+	errNotDirectory = errors.New("not a directory")
+)
+
+// Client is the subset of client.Client the file system depends on
+type Client interface {
+	HeadBucket(ctx context.Context, bucketName string) (*storageTypes.BucketInfo, error)
+	ListBuckets(ctx context.Context) (types.ListBucketsResult, error)
+	HeadObject(ctx context.Context, bucketName, objectName string) (*storageTypes.ObjectInfo, error)
+	ListObjects(ctx context.Context, bucketName string, opts types.ListObjectsOptions) (types.ListObjectsResult, error)
+	GetObject(ctx context.Context, bucketName, objectName string, opts types.GetObjectOption) (io.ReadCloser, types.ObjectStat, error)
+	CreateFolder(ctx context.Context, bucketName, objectName string, opts types.CreateObjectOptions) (string, error)
+	CreateObject(ctx context.Context, bucketName, objectName string, reader io.Reader, opts types.CreateObjectOptions) (string, error)
+	PutObject(ctx context.Context, bucketName, objectName string, objectSize int64, reader io.Reader, opts types.PutObjectOptions) error
+	DeleteObject(ctx context.Context, bucketName, objectName string, opt types.DeleteObjectOption) (string, error)
+	CancelCreateObject(ctx context.Context, bucketName, objectName string, opt types.CancelCreateOption) (string, error)
+	WaitForTxSuccess(ctx context.Context, hash string) (*types.TxResult, error)
+}
+
+var _ Client = client.Client(nil)
+
+// FileSystem is a webdav.FileSystem backed by the buckets and objects of a greenfield account
+type FileSystem struct {
+	client Client
+	// tempDir is the directory the uploaded payloads are spooled to, os.TempDir() if it is empty
+	tempDir string
+}
+
+var _ webdav.FileSystem = (*FileSystem)(nil)
+
+// New returns a FileSystem operating with the default account of the client
+func New(cli Client, tempDir string) *FileSystem {
+	return &FileSystem{client: cli, tempDir: tempDir}
+}
+
+// Mkdir creates a folder object in the bucket, buckets can not be created through webdav
+// since the primary SP has to be chosen
+func (fs *FileSystem) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	bucketName, objectName := splitPath(name)
+	if objectName == "" {
+		return os.ErrPermission
+	}
+
+	if _, err := fs.Stat(ctx, name); err == nil {
+		return os.ErrExist
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	// the parent of a folder is allowed to be a common prefix, only the bucket is required
+	if _, err := fs.client.HeadBucket(ctx, bucketName); err != nil {
+		return convertErr(err)
+	}
+
+	txHash, err := fs.client.CreateFolder(ctx, bucketName, objectName+"/", types.CreateObjectOptions{})
+	if err != nil {
+		return err
+	}
+	_, err = fs.client.WaitForTxSuccess(ctx, txHash)
+	return err
+}
+
+// OpenFile opens a directory or an object for reading, or an object for writing. Objects on
+// greenfield can not be modified, so a file opened for writing always replaces the whole object
+// when it is closed.
+func (fs *FileSystem) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	bucketName, objectName := splitPath(name)
+
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0 {
+		if objectName == "" {
+			return nil, os.ErrPermission
+		}
+		if flag&os.O_APPEND != 0 {
+			return nil, errNotSupported
+		}
+		if _, err := fs.client.HeadBucket(ctx, bucketName); err != nil {
+			return nil, convertErr(err)
+		}
+		return fs.newWriteFile(ctx, bucketName, objectName)
+	}
+
+	fi, err := fs.Stat(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return &dirFile{fs: fs, ctx: ctx, bucketName: bucketName, objectName: objectName, info: fi}, nil
+	}
+	return &readFile{fs: fs, ctx: ctx, bucketName: bucketName, objectName: objectName, info: fi.(*fileInfo)}, nil
+}
+
+// RemoveAll deletes the object, or all the objects under the directory. Buckets can not be
+// deleted through webdav.
+func (fs *FileSystem) RemoveAll(ctx context.Context, name string) error {
+	bucketName, objectName := splitPath(name)
+	if objectName == "" {
+		return os.ErrPermission
+	}
+
+	fi, err := fs.Stat(ctx, name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if !fi.IsDir() {
+		return fs.deleteObject(ctx, bucketName, objectName)
+	}
+
+	objects, err := fs.listObjects(ctx, bucketName, objectName+"/")
+	if err != nil {
+		return err
+	}
+	for _, objectInfo := range objects {
+		if err = fs.deleteObject(ctx, bucketName, objectInfo.ObjectName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rename is not supported since greenfield has no way to move objects
+func (fs *FileSystem) Rename(ctx context.Context, oldName, newName string) error {
+	return errNotSupported
+}
+
+// Stat returns the info of the root, a bucket, an object or a directory inside a bucket
+func (fs *FileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	bucketName, objectName := splitPath(name)
+	if bucketName == "" {
+		return &fileInfo{name: "/", isDir: true}, nil
+	}
+
+	if objectName == "" {
+		bucketInfo, err := fs.client.HeadBucket(ctx, bucketName)
+		if err != nil {
+			return nil, convertErr(err)
+		}
+		return &fileInfo{name: bucketName, isDir: true, modTime: time.Unix(bucketInfo.CreateAt, 0)}, nil
+	}
+
+	objectInfo, err := fs.client.HeadObject(ctx, bucketName, objectName)
+	if err == nil {
+		return newObjectFileInfo(path.Base(objectName), objectInfo.PayloadSize, objectInfo.CreateAt,
+			objectInfo.ContentType, objectInfo.Checksums), nil
+	}
+	if err = convertErr(err); !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	// the directory is either a folder object or the common prefix of other objects
+	folderInfo, err := fs.client.HeadObject(ctx, bucketName, objectName+"/")
+	if err == nil {
+		return &fileInfo{name: path.Base(objectName), isDir: true, modTime: time.Unix(folderInfo.CreateAt, 0)}, nil
+	}
+	if err = convertErr(err); !os.IsNotExist(err) {
+		return nil, err
+	}
+	objects, err := fs.listObjects(ctx, bucketName, objectName+"/")
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return &fileInfo{name: path.Base(objectName), isDir: true}, nil
+}
+
+// readDir lists the buckets of the account if bucketName is empty, otherwise the objects and
+// the sub directories of the directory
+func (fs *FileSystem) readDir(ctx context.Context, bucketName, objectName string) ([]os.FileInfo, error) {
+	if bucketName == "" {
+		listResult, err := fs.client.ListBuckets(ctx)
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]os.FileInfo, 0, len(listResult.Buckets))
+		for _, bucketMeta := range listResult.Buckets {
+			if bucketMeta.Removed || bucketMeta.BucketInfo == nil {
+				continue
+			}
+			infos = append(infos, &fileInfo{
+				name:    bucketMeta.BucketInfo.BucketName,
+				isDir:   true,
+				modTime: time.Unix(bucketMeta.BucketInfo.CreateAt, 0),
+			})
+		}
+		sortInfos(infos)
+		return infos, nil
+	}
+
+	prefix := ""
+	if objectName != "" {
+		prefix = objectName + "/"
+	}
+	objects, err := fs.listObjects(ctx, bucketName, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var infos []os.FileInfo
+	seenDirs := make(map[string]bool)
+	for _, objectInfo := range objects {
+		rest := strings.TrimPrefix(objectInfo.ObjectName, prefix)
+		if rest == "" {
+			// the folder object of the directory itself
+			continue
+		}
+		if dirName, _, isDir := strings.Cut(rest, "/"); isDir {
+			if !seenDirs[dirName] {
+				seenDirs[dirName] = true
+				infos = append(infos, &fileInfo{name: dirName, isDir: true, modTime: time.Unix(objectInfo.CreateAt, 0)})
+			}
+			continue
+		}
+		infos = append(infos, newObjectFileInfo(rest, objectInfo.PayloadSize, objectInfo.CreateAt,
+			objectInfo.ContentType, objectInfo.Checksums))
+	}
+	sortInfos(infos)
+	return infos, nil
+}
+
+// listObjects returns the objects of the bucket with the prefix, including the folder object of the prefix
+func (fs *FileSystem) listObjects(ctx context.Context, bucketName, prefix string) ([]*types.ObjectInfo, error) {
+	listResult, err := fs.client.ListObjects(ctx, bucketName, types.ListObjectsOptions{})
+	if err != nil {
+		return nil, convertErr(err)
+	}
+
+	var objects []*types.ObjectInfo
+	for _, objectMeta := range listResult.Objects {
+		if objectMeta.Removed || objectMeta.ObjectInfo == nil {
+			continue
+		}
+		if strings.HasPrefix(objectMeta.ObjectInfo.ObjectName, prefix) {
+			objects = append(objects, objectMeta.ObjectInfo)
+		}
+	}
+	return objects, nil
+}
+
+// deleteObject deletes the object, an object left unsealed by a failed upload is canceled
+func (fs *FileSystem) deleteObject(ctx context.Context, bucketName, objectName string) error {
+	if err := objectutil.Remove(ctx, fs.client, bucketName, objectName); err != nil {
+		return convertErr(err)
+	}
+	return nil
+}
+
+// splitPath gets the bucket and object name from a slash separated webdav path
+func splitPath(name string) (string, string) {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	bucketName, objectName, _ := strings.Cut(name, "/")
+	return bucketName, objectName
+}
+
+func sortInfos(infos []os.FileInfo) {
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name() < infos[j].Name()
+	})
+}
+
+// convertErr converts the not found errors of chain to os.ErrNotExist
+func convertErr(err error) error {
+	if objectutil.IsNotFound(err) {
+		return os.ErrNotExist
+	}
+	return err
+}
@@ -0,0 +1,467 @@
+package davfs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+const testBucketName = "davfs-test"
+
+// fakeObject is an object of fakeClient, the payload is set when it is sealed
+type fakeObject struct {
+	info    storageTypes.ObjectInfo
+	payload []byte
+}
+
+// fakeClient is an in-process stand-in of the chain and the primary SP
+type fakeClient struct {
+	mu      sync.Mutex
+	buckets map[string]map[string]*fakeObject
+	nextID  uint64
+	// failPut makes the payload uploads of the objects it returns true for fail
+	failPut func(objectName string) bool
+}
+
+func newFakeClient(bucketNames ...string) *fakeClient {
+	c := &fakeClient{buckets: make(map[string]map[string]*fakeObject)}
+	for _, name := range bucketNames {
+		c.buckets[name] = make(map[string]*fakeObject)
+	}
+	return c
+}
+
+func (c *fakeClient) getBucket(bucketName string) (map[string]*fakeObject, error) {
+	objects, ok := c.buckets[bucketName]
+	if !ok {
+		return nil, errors.New("rpc error: code = Unknown desc = " + storageTypes.ErrNoSuchBucket.Error())
+	}
+	return objects, nil
+}
+
+func (c *fakeClient) getObject(bucketName, objectName string) (*fakeObject, error) {
+	objects, err := c.getBucket(bucketName)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := objects[objectName]
+	if !ok {
+		return nil, errors.New("rpc error: code = Unknown desc = " + storageTypes.ErrNoSuchObject.Error())
+	}
+	return object, nil
+}
+
+func (c *fakeClient) HeadBucket(ctx context.Context, bucketName string) (*storageTypes.BucketInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.getBucket(bucketName); err != nil {
+		return nil, err
+	}
+	return &storageTypes.BucketInfo{BucketName: bucketName}, nil
+}
+
+func (c *fakeClient) ListBuckets(ctx context.Context) (types.ListBucketsResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result := types.ListBucketsResult{}
+	for name := range c.buckets {
+		result.Buckets = append(result.Buckets, &types.BucketMeta{BucketInfo: &types.BucketInfo{BucketName: name}})
+	}
+	return result, nil
+}
+
+func (c *fakeClient) HeadObject(ctx context.Context, bucketName, objectName string) (*storageTypes.ObjectInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, err := c.getObject(bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	info := object.info
+	return &info, nil
+}
+
+func (c *fakeClient) ListObjects(ctx context.Context, bucketName string, opts types.ListObjectsOptions) (types.ListObjectsResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	objects, err := c.getBucket(bucketName)
+	if err != nil {
+		return types.ListObjectsResult{}, err
+	}
+
+	result := types.ListObjectsResult{}
+	for _, object := range objects {
+		result.Objects = append(result.Objects, &types.ObjectMeta{ObjectInfo: &types.ObjectInfo{
+			BucketName:  object.info.BucketName,
+			ObjectName:  object.info.ObjectName,
+			PayloadSize: object.info.PayloadSize,
+			ContentType: object.info.ContentType,
+			CreateAt:    object.info.CreateAt,
+			Checksums:   object.info.Checksums,
+		}})
+	}
+	return result, nil
+}
+
+func (c *fakeClient) GetObject(ctx context.Context, bucketName, objectName string, opts types.GetObjectOption) (io.ReadCloser, types.ObjectStat, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, err := c.getObject(bucketName, objectName)
+	if err != nil || object.info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+		return nil, types.ObjectStat{}, types.ErrResponse{StatusCode: http.StatusNotFound, Code: "NoSuchKey"}
+	}
+	return io.NopCloser(bytes.NewReader(object.payload)), types.ObjectStat{ObjectName: objectName}, nil
+}
+
+func (c *fakeClient) CreateFolder(ctx context.Context, bucketName, objectName string, opts types.CreateObjectOptions) (string, error) {
+	return c.CreateObject(ctx, bucketName, objectName, bytes.NewReader(nil), opts)
+}
+
+func (c *fakeClient) CreateObject(ctx context.Context, bucketName, objectName string, reader io.Reader, opts types.CreateObjectOptions) (string, error) {
+	payload, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	objects, err := c.getBucket(bucketName)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := objects[objectName]; ok {
+		return "", storageTypes.ErrObjectAlreadyExists
+	}
+
+	c.nextID++
+	checksum := sha256.Sum256(payload)
+	objects[objectName] = &fakeObject{
+		info: storageTypes.ObjectInfo{
+			BucketName:  bucketName,
+			ObjectName:  objectName,
+			Id:          sdk.NewUint(c.nextID),
+			PayloadSize: uint64(len(payload)),
+			ContentType: opts.ContentType,
+			CreateAt:    time.Now().Unix(),
+			Checksums:   [][]byte{checksum[:]},
+		},
+	}
+	// the empty object is sealed on creation
+	if len(payload) == 0 {
+		objects[objectName].info.ObjectStatus = storageTypes.OBJECT_STATUS_SEALED
+	}
+	return fmt.Sprintf("%064x", c.nextID), nil
+}
+
+func (c *fakeClient) PutObject(ctx context.Context, bucketName, objectName string, objectSize int64, reader io.Reader, opts types.PutObjectOptions) error {
+	payload, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failPut != nil && c.failPut(objectName) {
+		return types.ErrResponse{StatusCode: http.StatusServiceUnavailable, Code: "ServiceUnavailable"}
+	}
+	object, err := c.getObject(bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	checksum := sha256.Sum256(payload)
+	if int64(len(payload)) != objectSize || !bytes.Equal(checksum[:], object.info.Checksums[0]) {
+		return types.ErrResponse{StatusCode: http.StatusBadRequest, Code: "InvalidPayload"}
+	}
+	object.payload = payload
+	object.info.ObjectStatus = storageTypes.OBJECT_STATUS_SEALED
+	return nil
+}
+
+func (c *fakeClient) DeleteObject(ctx context.Context, bucketName, objectName string, opt types.DeleteObjectOption) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, err := c.getObject(bucketName, objectName)
+	if err != nil {
+		return "", err
+	}
+	if object.info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+		return "", storageTypes.ErrObjectNotSealed
+	}
+	delete(c.buckets[bucketName], objectName)
+	return "", nil
+}
+
+func (c *fakeClient) CancelCreateObject(ctx context.Context, bucketName, objectName string, opt types.CancelCreateOption) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, err := c.getObject(bucketName, objectName)
+	if err != nil {
+		return "", err
+	}
+	if object.info.ObjectStatus != storageTypes.OBJECT_STATUS_CREATED {
+		return "", storageTypes.ErrObjectNotCreated
+	}
+	delete(c.buckets[bucketName], objectName)
+	return "", nil
+}
+
+func (c *fakeClient) WaitForTxSuccess(ctx context.Context, hash string) (*types.TxResult, error) {
+	return &types.TxResult{TxHash: hash}, nil
+}
+
+// objectNames returns the sorted names of the objects in the bucket
+func (c *fakeClient) objectNames(bucketName string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var names []string
+	for name := range c.buckets[bucketName] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func writeTestFile(t *testing.T, fs *FileSystem, name, content string) error {
+	f, err := fs.OpenFile(context.Background(), name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = io.WriteString(f, content); err != nil {
+		t.Fatal(err)
+	}
+	return f.Close()
+}
+
+func readTestFile(t *testing.T, fs *FileSystem, name string) string {
+	f, err := fs.OpenFile(context.Background(), name, os.O_RDONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSplitPath(t *testing.T) {
+	cases := []struct {
+		name, bucketName, objectName string
+	}{
+		{"/", "", ""},
+		{"", "", ""},
+		{"/bucket", "bucket", ""},
+		{"/bucket/", "bucket", ""},
+		{"/bucket/a.txt", "bucket", "a.txt"},
+		{"/bucket/dir/", "bucket", "dir"},
+		{"/bucket/dir/../b.txt", "bucket", "b.txt"},
+		{"bucket//dir//c.txt", "bucket", "dir/c.txt"},
+	}
+	for _, c := range cases {
+		bucketName, objectName := splitPath(c.name)
+		if bucketName != c.bucketName || objectName != c.objectName {
+			t.Errorf("%q: got %q %q", c.name, bucketName, objectName)
+		}
+	}
+}
+
+func TestMkdir(t *testing.T) {
+	ctx := context.Background()
+	cli := newFakeClient(testBucketName)
+	fs := New(cli, t.TempDir())
+
+	if err := fs.Mkdir(ctx, "/"+testBucketName+"/dir", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if names := cli.objectNames(testBucketName); len(names) != 1 || names[0] != "dir/" {
+		t.Errorf("unexpected objects %v", names)
+	}
+	if err := fs.Mkdir(ctx, "/"+testBucketName+"/dir", 0o755); !os.IsExist(err) {
+		t.Errorf("expect os.ErrExist, got %v", err)
+	}
+	if err := fs.Mkdir(ctx, "/new-bucket", 0o755); !os.IsPermission(err) {
+		t.Errorf("expect os.ErrPermission, got %v", err)
+	}
+	if err := fs.Mkdir(ctx, "/no-bucket/dir", 0o755); !os.IsNotExist(err) {
+		t.Errorf("expect os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestPutAndStat(t *testing.T) {
+	ctx := context.Background()
+	cli := newFakeClient(testBucketName)
+	fs := New(cli, t.TempDir())
+
+	if err := writeTestFile(t, fs, "/"+testBucketName+"/dir/a.txt", "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeTestFile(t, fs, "/"+testBucketName+"/empty.txt", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := fs.Stat(ctx, "/"+testBucketName+"/dir/a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.IsDir() || fi.Size() != 5 || fi.Name() != "a.txt" {
+		t.Errorf("unexpected file info %+v", fi)
+	}
+	if got := readTestFile(t, fs, "/"+testBucketName+"/dir/a.txt"); got != "hello" {
+		t.Errorf("unexpected content %q", got)
+	}
+
+	// the common prefix of objects is a directory
+	fi, err = fs.Stat(ctx, "/"+testBucketName+"/dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fi.IsDir() {
+		t.Errorf("expect a directory")
+	}
+	if _, err = fs.Stat(ctx, "/"+testBucketName+"/missing"); !os.IsNotExist(err) {
+		t.Errorf("expect os.ErrNotExist, got %v", err)
+	}
+
+	f, err := fs.OpenFile(ctx, "/"+testBucketName, os.O_RDONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	infos, err := f.Readdir(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 2 || infos[0].Name() != "dir" || !infos[0].IsDir() || infos[1].Name() != "empty.txt" {
+		t.Errorf("unexpected entries %v", infos)
+	}
+
+	if _, err = fs.OpenFile(ctx, "/"+testBucketName+"/a.txt", os.O_WRONLY|os.O_APPEND, 0o644); err != errNotSupported {
+		t.Errorf("expect errNotSupported, got %v", err)
+	}
+}
+
+func TestOverwrite(t *testing.T) {
+	cli := newFakeClient(testBucketName)
+	fs := New(cli, t.TempDir())
+	name := "/" + testBucketName + "/a.txt"
+
+	if err := writeTestFile(t, fs, name, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeTestFile(t, fs, name, "version 2"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readTestFile(t, fs, name); got != "version 2" {
+		t.Errorf("unexpected content %q", got)
+	}
+	// the staging object is deleted once the object is replaced
+	if names := cli.objectNames(testBucketName); len(names) != 1 || names[0] != "a.txt" {
+		t.Errorf("unexpected objects %v", names)
+	}
+}
+
+func TestOverwriteFailure(t *testing.T) {
+	name := "/" + testBucketName + "/a.txt"
+
+	// the existing object is kept if the payload fails to be staged
+	cli := newFakeClient(testBucketName)
+	fs := New(cli, t.TempDir())
+	if err := writeTestFile(t, fs, name, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	cli.failPut = func(objectName string) bool { return strings.Contains(objectName, ".staging-") }
+	if err := writeTestFile(t, fs, name, "version 2"); err == nil {
+		t.Fatal("expect error")
+	}
+	if got := readTestFile(t, fs, name); got != "v1" {
+		t.Errorf("unexpected content %q", got)
+	}
+	// the created staging object is canceled
+	if names := cli.objectNames(testBucketName); len(names) != 1 || names[0] != "a.txt" {
+		t.Errorf("unexpected objects %v", names)
+	}
+
+	// the new payload is kept in the staging object if it fails to be uploaded under the name
+	cli = newFakeClient(testBucketName)
+	fs = New(cli, t.TempDir())
+	if err := writeTestFile(t, fs, name, "v1"); err != nil {
+		t.Fatal(err)
+	}
+	cli.failPut = func(objectName string) bool { return objectName == "a.txt" }
+	if err := writeTestFile(t, fs, name, "version 2"); err == nil {
+		t.Fatal("expect error")
+	}
+	names := cli.objectNames(testBucketName)
+	if len(names) != 1 || !strings.HasPrefix(names[0], ".a.txt.staging-") {
+		t.Fatalf("unexpected objects %v", names)
+	}
+	if got := readTestFile(t, fs, "/"+testBucketName+"/"+names[0]); got != "version 2" {
+		t.Errorf("unexpected staged content %q", got)
+	}
+}
+
+func TestRemoveAll(t *testing.T) {
+	ctx := context.Background()
+	cli := newFakeClient(testBucketName)
+	fs := New(cli, t.TempDir())
+
+	for _, name := range []string{"a.txt", "dir/b.txt", "dir/sub/c.txt"} {
+		if err := writeTestFile(t, fs, "/"+testBucketName+"/"+name, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// an object left unsealed by a failed upload is canceled
+	if _, err := cli.CreateObject(ctx, testBucketName, "dir/unsealed.txt", strings.NewReader("unsealed"),
+		types.CreateObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fs.RemoveAll(ctx, "/"+testBucketName+"/a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.RemoveAll(ctx, "/"+testBucketName+"/dir"); err != nil {
+		t.Fatal(err)
+	}
+	if names := cli.objectNames(testBucketName); len(names) != 0 {
+		t.Errorf("unexpected objects %v", names)
+	}
+	// removing a missing path is not an error
+	if err := fs.RemoveAll(ctx, "/"+testBucketName+"/missing"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := fs.RemoveAll(ctx, "/"+testBucketName); !os.IsPermission(err) {
+		t.Errorf("expect os.ErrPermission, got %v", err)
+	}
+}
+
+func TestRename(t *testing.T) {
+	cli := newFakeClient(testBucketName)
+	fs := New(cli, t.TempDir())
+
+	if err := writeTestFile(t, fs, "/"+testBucketName+"/a.txt", "a"); err != nil {
+		t.Fatal(err)
+	}
+	err := fs.Rename(context.Background(), "/"+testBucketName+"/a.txt", "/"+testBucketName+"/b.txt")
+	if err != errNotSupported {
+		t.Errorf("expect errNotSupported, got %v", err)
+	}
+	if names := cli.objectNames(testBucketName); len(names) != 1 || names[0] != "a.txt" {
+		t.Errorf("unexpected objects %v", names)
+	}
+}
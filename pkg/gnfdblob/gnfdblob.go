@@ -0,0 +1,508 @@
+// Package gnfdblob provides a gocloud.dev/blob driver backed by greenfield.
+//
+// # URLs
+//
+// For blob.OpenBucket, gnfdblob registers for the scheme "greenfield", e.g.
+//
+//	greenfield://my-bucket?chain_id=greenfield_9000-121&rpc_addr=http://localhost:26750
+//
+// rpc_addr is the tendermint rpc address of the greenfield node. The private key of the account
+// is read from the GREENFIELD_PRIVATE_KEY environment variable, chain_id and rpc_addr default to
+// GREENFIELD_CHAIN_ID and GREENFIELD_RPC_ADDR. To customize the URL opener, or for more details
+// on the URL format, see URLOpener.
+//
+// # Attributes
+//
+// Greenfield keeps only the content type of objects, so CacheControl, ContentDisposition,
+// ContentEncoding, ContentLanguage and Metadata are encoded as a parameter of the stored
+// content type and decoded when the attributes are read.
+//
+// # As
+//
+// gnfdblob exposes the following types for As:
+//   - Bucket: Client
+//   - Error: types.ErrResponse
+//   - ListObject: types.ObjectInfo
+//   - ListOptions.BeforeList: *types.ListObjectsOptions
+//   - Reader: storageTypes.ObjectInfo
+//   - ReaderOptions.BeforeRead: *types.GetObjectOption
+//   - Attributes: storageTypes.ObjectInfo
+//   - WriterOptions.BeforeWrite: *types.CreateObjectOptions
+package gnfdblob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gnfdErrors "github.com/bnb-chain/greenfield/types/errors"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/driver"
+	"gocloud.dev/gcerrors"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/objectutil"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// Scheme is the URL scheme gnfdblob registers its URLOpener under on blob.DefaultMux
+const Scheme = "greenfield"
+
+const (
+	envChainID    = "GREENFIELD_CHAIN_ID"
+	envRpcAddr    = "GREENFIELD_RPC_ADDR"
+	envPrivateKey = "GREENFIELD_PRIVATE_KEY"
+
+	defaultPageSize = 1000
+)
+
+var errNotImplemented = errors.New("gnfdblob: not implemented")
+
+// Client is the subset of client.Client the driver depends on
+type Client interface {
+	HeadObject(ctx context.Context, bucketName, objectName string) (*storageTypes.ObjectInfo, error)
+	ListObjects(ctx context.Context, bucketName string, opts types.ListObjectsOptions) (types.ListObjectsResult, error)
+	GetObject(ctx context.Context, bucketName, objectName string, opts types.GetObjectOption) (io.ReadCloser, types.ObjectStat, error)
+	CreateObject(ctx context.Context, bucketName, objectName string, reader io.Reader, opts types.CreateObjectOptions) (string, error)
+	PutObject(ctx context.Context, bucketName, objectName string, objectSize int64, reader io.Reader, opts types.PutObjectOptions) error
+	DeleteObject(ctx context.Context, bucketName, objectName string, opt types.DeleteObjectOption) (string, error)
+	CancelCreateObject(ctx context.Context, bucketName, objectName string, opt types.CancelCreateOption) (string, error)
+	WaitForTxSuccess(ctx context.Context, hash string) (*types.TxResult, error)
+}
+
+var _ Client = client.Client(nil)
+
+func init() {
+	blob.DefaultURLMux().RegisterBucket(Scheme, &lazyClientOpener{clients: make(map[string]Client)})
+}
+
+// lazyClientOpener creates the clients from the environment variables on first use
+type lazyClientOpener struct {
+	mu      sync.Mutex
+	clients map[string]Client
+}
+
+func (o *lazyClientOpener) OpenBucketURL(ctx context.Context, u *url.URL) (*blob.Bucket, error) {
+	query := u.Query()
+	chainID, rpcAddr := os.Getenv(envChainID), os.Getenv(envRpcAddr)
+	if v := query.Get("chain_id"); v != "" {
+		chainID = v
+	}
+	if v := query.Get("rpc_addr"); v != "" {
+		rpcAddr = v
+	}
+	query.Del("chain_id")
+	query.Del("rpc_addr")
+	if chainID == "" || rpcAddr == "" {
+		return nil, fmt.Errorf("open bucket %v: chain_id and rpc_addr must be set", u)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	cli, ok := o.clients[chainID+"@"+rpcAddr]
+	if !ok {
+		account, err := types.NewAccountFromPrivateKey("gnfdblob", os.Getenv(envPrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("open bucket %v: load account from %s failed: %w", u, envPrivateKey, err)
+		}
+		cli, err = client.New(chainID, rpcAddr, client.Option{DefaultAccount: account})
+		if err != nil {
+			return nil, fmt.Errorf("open bucket %v: %w", u, err)
+		}
+		o.clients[chainID+"@"+rpcAddr] = cli
+	}
+
+	opener := &URLOpener{Client: cli}
+	u2 := *u
+	u2.RawQuery = query.Encode()
+	return opener.OpenBucketURL(ctx, &u2)
+}
+
+// URLOpener opens greenfield URLs like "greenfield://my-bucket" with the given client.
+// The URL host is used as the bucket name. The following query parameters are supported:
+//   - tempdir: the directory the written blobs are spooled to before uploading
+type URLOpener struct {
+	// Client must be set to a non-nil value
+	Client Client
+	// Options specifies the options to pass to OpenBucket
+	Options Options
+}
+
+// OpenBucketURL opens a blob.Bucket based on u
+func (o *URLOpener) OpenBucketURL(ctx context.Context, u *url.URL) (*blob.Bucket, error) {
+	opts := o.Options
+	for param, values := range u.Query() {
+		switch param {
+		case "tempdir":
+			opts.TempDir = values[0]
+		default:
+			return nil, fmt.Errorf("open bucket %v: invalid query parameter %q", u, param)
+		}
+	}
+	return OpenBucket(ctx, o.Client, u.Host, &opts)
+}
+
+// Options sets options for constructing a *blob.Bucket backed by greenfield
+type Options struct {
+	// TempDir is the directory the written blobs are spooled to, os.TempDir() if it is empty.
+	// The payload of an object has to be complete before it is created, since its integrity
+	// hash is committed on chain.
+	TempDir string
+}
+
+// OpenBucket returns a *blob.Bucket backed by the greenfield bucket of the client's account
+func OpenBucket(ctx context.Context, cli Client, bucketName string, opts *Options) (*blob.Bucket, error) {
+	drv, err := openBucket(cli, bucketName, opts)
+	if err != nil {
+		return nil, err
+	}
+	return blob.NewBucket(drv), nil
+}
+
+func openBucket(cli Client, bucketName string, opts *Options) (*bucket, error) {
+	if cli == nil {
+		return nil, errors.New("gnfdblob.OpenBucket: client is required")
+	}
+	if bucketName == "" {
+		return nil, errors.New("gnfdblob.OpenBucket: bucketName is required")
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	return &bucket{client: cli, name: bucketName, tempDir: opts.TempDir}, nil
+}
+
+// bucket implements driver.Bucket
+type bucket struct {
+	client  Client
+	name    string
+	tempDir string
+}
+
+var _ driver.Bucket = (*bucket)(nil)
+
+func (b *bucket) Close() error {
+	return nil
+}
+
+// ErrorCode implements driver.ErrorCode, the errors of chain are transferred by grpc
+// so only the message can be matched
+func (b *bucket) ErrorCode(err error) gcerrors.ErrorCode {
+	var spErr types.ErrResponse
+	if errors.As(err, &spErr) {
+		switch spErr.StatusCode {
+		case http.StatusNotFound:
+			return gcerrors.NotFound
+		case http.StatusForbidden, http.StatusUnauthorized:
+			return gcerrors.PermissionDenied
+		case http.StatusBadRequest, http.StatusRequestedRangeNotSatisfiable:
+			return gcerrors.InvalidArgument
+		}
+		return gcerrors.Unknown
+	}
+
+	if errors.Is(err, errNotImplemented) {
+		return gcerrors.Unimplemented
+	}
+	if errors.Is(err, gnfdErrors.ErrInvalidObjectName) {
+		return gcerrors.InvalidArgument
+	}
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, storageTypes.ErrNoSuchObject.Error()),
+		strings.Contains(msg, storageTypes.ErrNoSuchBucket.Error()):
+		return gcerrors.NotFound
+	case strings.Contains(msg, storageTypes.ErrObjectAlreadyExists.Error()):
+		return gcerrors.AlreadyExists
+	}
+	return gcerrors.Unknown
+}
+
+// As implements driver.As
+func (b *bucket) As(i interface{}) bool {
+	p, ok := i.(*Client)
+	if !ok {
+		return false
+	}
+	*p = b.client
+	return true
+}
+
+// ErrorAs implements driver.ErrorAs
+func (b *bucket) ErrorAs(err error, i interface{}) bool {
+	switch v := i.(type) {
+	case *types.ErrResponse:
+		return errors.As(err, v)
+	}
+	return false
+}
+
+// Attributes implements driver.Attributes
+func (b *bucket) Attributes(ctx context.Context, key string) (*driver.Attributes, error) {
+	objectInfo, err := b.client.HeadObject(ctx, b.name, escapeKey(key))
+	if err != nil {
+		return nil, err
+	}
+
+	contentType, attrs := decodeContentType(objectInfo.ContentType)
+	modTime := time.Unix(objectInfo.CreateAt, 0)
+	return &driver.Attributes{
+		CacheControl:       attrs.CacheControl,
+		ContentDisposition: attrs.ContentDisposition,
+		ContentEncoding:    attrs.ContentEncoding,
+		ContentLanguage:    attrs.ContentLanguage,
+		ContentType:        contentType,
+		Metadata:           attrs.Metadata,
+		CreateTime:         modTime,
+		ModTime:            modTime,
+		Size:               int64(objectInfo.PayloadSize),
+		ETag:               getETag(objectInfo),
+		AsFunc: func(i interface{}) bool {
+			p, ok := i.(*storageTypes.ObjectInfo)
+			if !ok {
+				return false
+			}
+			*p = *objectInfo
+			return true
+		},
+	}, nil
+}
+
+// ListPaged implements driver.ListPaged. The SP returns all the objects of the bucket,
+// so the prefix, delimiter and pagination are applied by the driver on the unescaped keys.
+func (b *bucket) ListPaged(ctx context.Context, opts *driver.ListOptions) (*driver.ListPage, error) {
+	listOpts := types.ListObjectsOptions{}
+	if opts.BeforeList != nil {
+		asFunc := func(i interface{}) bool {
+			p, ok := i.(**types.ListObjectsOptions)
+			if !ok {
+				return false
+			}
+			*p = &listOpts
+			return true
+		}
+		if err := opts.BeforeList(asFunc); err != nil {
+			return nil, err
+		}
+	}
+
+	listResult, err := b.client.ListObjects(ctx, b.name, listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		key  string
+		info *types.ObjectInfo
+	}
+	entries := make([]entry, 0, len(listResult.Objects))
+	for _, objectMeta := range listResult.Objects {
+		if objectMeta.Removed || objectMeta.ObjectInfo == nil {
+			continue
+		}
+		key := unescapeKey(objectMeta.ObjectInfo.ObjectName)
+		if strings.HasPrefix(key, opts.Prefix) {
+			entries = append(entries, entry{key: key, info: objectMeta.ObjectInfo})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	// the page token is the last key or common prefix of the previous page
+	marker := string(opts.PageToken)
+
+	page := &driver.ListPage{}
+	var lastKey string
+	for _, e := range entries {
+		key := e.key
+		dirKey := ""
+		if opts.Delimiter != "" {
+			if idx := strings.Index(key[len(opts.Prefix):], opts.Delimiter); idx >= 0 {
+				dirKey = key[:len(opts.Prefix)+idx+len(opts.Delimiter)]
+			}
+		}
+		if dirKey != "" {
+			key = dirKey
+		}
+		if marker != "" && key <= marker || key == lastKey {
+			continue
+		}
+
+		if len(page.Objects) == pageSize {
+			page.NextPageToken = []byte(lastKey)
+			break
+		}
+
+		if dirKey != "" {
+			page.Objects = append(page.Objects, &driver.ListObject{
+				Key:    dirKey,
+				IsDir:  true,
+				AsFunc: func(interface{}) bool { return false },
+			})
+		} else {
+			objectInfo := e.info
+			page.Objects = append(page.Objects, &driver.ListObject{
+				Key:     key,
+				ModTime: time.Unix(objectInfo.CreateAt, 0),
+				Size:    int64(objectInfo.PayloadSize),
+				AsFunc: func(i interface{}) bool {
+					p, ok := i.(*types.ObjectInfo)
+					if !ok {
+						return false
+					}
+					*p = *objectInfo
+					return true
+				},
+			})
+		}
+		lastKey = key
+	}
+	return page, nil
+}
+
+// NewRangeReader implements driver.NewRangeReader, the object meta is queried from chain
+// and the payload is downloaded from the primary SP with range
+func (b *bucket) NewRangeReader(ctx context.Context, key string, offset, length int64, opts *driver.ReaderOptions) (driver.Reader, error) {
+	objectName := escapeKey(key)
+	objectInfo, err := b.client.HeadObject(ctx, b.name, objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	getOpts := types.GetObjectOption{}
+	if opts.BeforeRead != nil {
+		asFunc := func(i interface{}) bool {
+			p, ok := i.(**types.GetObjectOption)
+			if !ok {
+				return false
+			}
+			*p = &getOpts
+			return true
+		}
+		if err = opts.BeforeRead(asFunc); err != nil {
+			return nil, err
+		}
+	}
+
+	contentType, _ := decodeContentType(objectInfo.ContentType)
+	r := &reader{
+		attrs: driver.ReaderAttributes{
+			ContentType: contentType,
+			ModTime:     time.Unix(objectInfo.CreateAt, 0),
+			Size:        int64(objectInfo.PayloadSize),
+		},
+		objectInfo: objectInfo,
+	}
+
+	size := int64(objectInfo.PayloadSize)
+	end := size - 1
+	if length >= 0 && offset+length-1 < end {
+		end = offset + length - 1
+	}
+	if offset >= size || end < offset {
+		r.body = http.NoBody
+		return r, nil
+	}
+
+	if offset > 0 || end < size-1 {
+		if err = getOpts.SetRange(offset, end); err != nil {
+			return nil, err
+		}
+	}
+	body, _, err := b.client.GetObject(ctx, b.name, objectName, getOpts)
+	if err != nil {
+		return nil, err
+	}
+	r.body = body
+	return r, nil
+}
+
+// NewTypedWriter implements driver.NewTypedWriter, the blob is spooled to a temp file and
+// uploaded when the writer is closed
+func (b *bucket) NewTypedWriter(ctx context.Context, key, contentType string, opts *driver.WriterOptions) (driver.Writer, error) {
+	createOpts := types.CreateObjectOptions{}
+	if opts.BeforeWrite != nil {
+		asFunc := func(i interface{}) bool {
+			p, ok := i.(**types.CreateObjectOptions)
+			if !ok {
+				return false
+			}
+			*p = &createOpts
+			return true
+		}
+		if err := opts.BeforeWrite(asFunc); err != nil {
+			return nil, err
+		}
+	}
+
+	createOpts.ContentType = encodeContentType(contentType, blobAttrs{
+		CacheControl:       opts.CacheControl,
+		ContentDisposition: opts.ContentDisposition,
+		ContentEncoding:    opts.ContentEncoding,
+		ContentLanguage:    opts.ContentLanguage,
+		Metadata:           opts.Metadata,
+	})
+	return b.newWriter(ctx, escapeKey(key), createOpts)
+}
+
+// Copy implements driver.Copy, greenfield has no copy operation so the blob is downloaded and uploaded
+func (b *bucket) Copy(ctx context.Context, dstKey, srcKey string, opts *driver.CopyOptions) error {
+	if opts.BeforeCopy != nil {
+		if err := opts.BeforeCopy(func(interface{}) bool { return false }); err != nil {
+			return err
+		}
+	}
+
+	srcName := escapeKey(srcKey)
+	objectInfo, err := b.client.HeadObject(ctx, b.name, srcName)
+	if err != nil {
+		return err
+	}
+
+	w, err := b.newWriter(ctx, escapeKey(dstKey), types.CreateObjectOptions{
+		Visibility:  objectInfo.Visibility,
+		ContentType: objectInfo.ContentType,
+	})
+	if err != nil {
+		return err
+	}
+	if objectInfo.PayloadSize > 0 {
+		body, _, err := b.client.GetObject(ctx, b.name, srcName, types.GetObjectOption{})
+		if err != nil {
+			w.abort()
+			return err
+		}
+		_, err = io.Copy(w, body)
+		body.Close()
+		if err != nil {
+			w.abort()
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// Delete implements driver.Delete, an object left unsealed by a failed upload is canceled
+func (b *bucket) Delete(ctx context.Context, key string) error {
+	return objectutil.Remove(ctx, b.client, b.name, escapeKey(key))
+}
+
+// SignedURL implements driver.SignedURL, it is not supported since the SP authenticates
+// the requests with the signature of the account
+func (b *bucket) SignedURL(ctx context.Context, key string, opts *driver.SignedURLOptions) (string, error) {
+	return "", errNotImplemented
+}
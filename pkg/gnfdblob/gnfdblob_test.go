@@ -0,0 +1,411 @@
+package gnfdblob
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bnb-chain/greenfield/types/s3util"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/driver"
+	"gocloud.dev/blob/drivertest"
+	"gocloud.dev/gcerrors"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+const testBucketName = "gnfdblob-test"
+
+// fakeObject is an object of fakeClient, the payload is set when it is sealed
+type fakeObject struct {
+	info    storageTypes.ObjectInfo
+	payload []byte
+}
+
+// fakeClient is an in-process stand-in of the chain and the primary SP, it follows the rules of
+// greenfield on object names, object creation and the payload upload
+type fakeClient struct {
+	mu      sync.Mutex
+	buckets map[string]map[string]*fakeObject
+	nextID  uint64
+	// failPut makes the payload uploads of the objects it returns true for fail
+	failPut func(objectName string) bool
+}
+
+func newFakeClient(bucketNames ...string) *fakeClient {
+	c := &fakeClient{buckets: make(map[string]map[string]*fakeObject)}
+	for _, name := range bucketNames {
+		c.buckets[name] = make(map[string]*fakeObject)
+	}
+	return c
+}
+
+func (c *fakeClient) getBucket(bucketName string) (map[string]*fakeObject, error) {
+	objects, ok := c.buckets[bucketName]
+	if !ok {
+		return nil, errors.New("rpc error: code = Unknown desc = " + storageTypes.ErrNoSuchBucket.Error())
+	}
+	return objects, nil
+}
+
+func (c *fakeClient) getObject(bucketName, objectName string) (*fakeObject, error) {
+	objects, err := c.getBucket(bucketName)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := objects[objectName]
+	if !ok {
+		return nil, errors.New("rpc error: code = Unknown desc = " + storageTypes.ErrNoSuchObject.Error())
+	}
+	return object, nil
+}
+
+func (c *fakeClient) HeadObject(ctx context.Context, bucketName, objectName string) (*storageTypes.ObjectInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, err := c.getObject(bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+	info := object.info
+	return &info, nil
+}
+
+func (c *fakeClient) ListObjects(ctx context.Context, bucketName string, opts types.ListObjectsOptions) (types.ListObjectsResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	objects, err := c.getBucket(bucketName)
+	if err != nil {
+		return types.ListObjectsResult{}, err
+	}
+
+	result := types.ListObjectsResult{}
+	for _, object := range objects {
+		result.Objects = append(result.Objects, &types.ObjectMeta{ObjectInfo: &types.ObjectInfo{
+			Owner:       object.info.Owner,
+			BucketName:  object.info.BucketName,
+			ObjectName:  object.info.ObjectName,
+			Id:          object.info.Id,
+			PayloadSize: object.info.PayloadSize,
+			ContentType: object.info.ContentType,
+			CreateAt:    object.info.CreateAt,
+			Checksums:   object.info.Checksums,
+		}})
+	}
+	return result, nil
+}
+
+func (c *fakeClient) GetObject(ctx context.Context, bucketName, objectName string, opts types.GetObjectOption) (io.ReadCloser, types.ObjectStat, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, err := c.getObject(bucketName, objectName)
+	if err != nil || object.info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+		return nil, types.ObjectStat{}, types.ErrResponse{StatusCode: http.StatusNotFound, Code: "NoSuchKey"}
+	}
+
+	payload := object.payload
+	if opts.Range != "" {
+		startStr, endStr, _ := strings.Cut(strings.TrimPrefix(opts.Range, "bytes="), "-")
+		start, _ := strconv.Atoi(startStr)
+		end := len(payload) - 1
+		if endStr != "" {
+			end, _ = strconv.Atoi(endStr)
+		}
+		if start >= len(payload) || end < start {
+			return nil, types.ObjectStat{}, types.ErrResponse{StatusCode: http.StatusRequestedRangeNotSatisfiable}
+		}
+		if end >= len(payload) {
+			end = len(payload) - 1
+		}
+		payload = payload[start : end+1]
+	}
+	return io.NopCloser(bytes.NewReader(payload)), types.ObjectStat{
+		ObjectName:  objectName,
+		ContentType: object.info.ContentType,
+		Size:        int64(object.info.PayloadSize),
+	}, nil
+}
+
+func (c *fakeClient) CreateObject(ctx context.Context, bucketName, objectName string, reader io.Reader, opts types.CreateObjectOptions) (string, error) {
+	if err := s3util.CheckValidObjectName(objectName); err != nil {
+		return "", err
+	}
+	payload, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	objects, err := c.getBucket(bucketName)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := objects[objectName]; ok {
+		return "", storageTypes.ErrObjectAlreadyExists
+	}
+
+	c.nextID++
+	checksum := sha256.Sum256(payload)
+	objects[objectName] = &fakeObject{
+		info: storageTypes.ObjectInfo{
+			BucketName:  bucketName,
+			ObjectName:  objectName,
+			Id:          sdk.NewUint(c.nextID),
+			PayloadSize: uint64(len(payload)),
+			Visibility:  opts.Visibility,
+			ContentType: opts.ContentType,
+			CreateAt:    time.Now().Unix(),
+			Checksums:   [][]byte{checksum[:]},
+		},
+	}
+	// the empty object is sealed on creation
+	if len(payload) == 0 {
+		objects[objectName].info.ObjectStatus = storageTypes.OBJECT_STATUS_SEALED
+	}
+	return fmt.Sprintf("%064x", c.nextID), nil
+}
+
+func (c *fakeClient) PutObject(ctx context.Context, bucketName, objectName string, objectSize int64, reader io.Reader, opts types.PutObjectOptions) error {
+	payload, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failPut != nil && c.failPut(objectName) {
+		return types.ErrResponse{StatusCode: http.StatusServiceUnavailable, Code: "ServiceUnavailable"}
+	}
+	object, err := c.getObject(bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	checksum := sha256.Sum256(payload)
+	if int64(len(payload)) != objectSize || !bytes.Equal(checksum[:], object.info.Checksums[0]) {
+		return types.ErrResponse{StatusCode: http.StatusBadRequest, Code: "InvalidPayload"}
+	}
+	object.payload = payload
+	object.info.ObjectStatus = storageTypes.OBJECT_STATUS_SEALED
+	return nil
+}
+
+func (c *fakeClient) DeleteObject(ctx context.Context, bucketName, objectName string, opt types.DeleteObjectOption) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, err := c.getObject(bucketName, objectName)
+	if err != nil {
+		return "", err
+	}
+	if object.info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+		return "", storageTypes.ErrObjectNotSealed
+	}
+	delete(c.buckets[bucketName], objectName)
+	return "0x" + hex.EncodeToString(object.info.Checksums[0]), nil
+}
+
+func (c *fakeClient) CancelCreateObject(ctx context.Context, bucketName, objectName string, opt types.CancelCreateOption) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, err := c.getObject(bucketName, objectName)
+	if err != nil {
+		return "", err
+	}
+	if object.info.ObjectStatus != storageTypes.OBJECT_STATUS_CREATED {
+		return "", storageTypes.ErrObjectNotCreated
+	}
+	delete(c.buckets[bucketName], objectName)
+	return "0x" + hex.EncodeToString(object.info.Checksums[0]), nil
+}
+
+func (c *fakeClient) WaitForTxSuccess(ctx context.Context, hash string) (*types.TxResult, error) {
+	return &types.TxResult{TxHash: hash}, nil
+}
+
+// objectNames returns the sorted names of the objects in the bucket
+func (c *fakeClient) objectNames(bucketName string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var names []string
+	for name := range c.buckets[bucketName] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type harness struct {
+	client  *fakeClient
+	tempDir string
+}
+
+func newHarness(ctx context.Context, t *testing.T) (drivertest.Harness, error) {
+	return &harness{client: newFakeClient(testBucketName), tempDir: t.TempDir()}, nil
+}
+
+func (h *harness) MakeDriver(ctx context.Context) (driver.Bucket, error) {
+	return openBucket(h.client, testBucketName, &Options{TempDir: h.tempDir})
+}
+
+func (h *harness) MakeDriverForNonexistentBucket(ctx context.Context) (driver.Bucket, error) {
+	return openBucket(h.client, "bucket-does-not-exist", &Options{TempDir: h.tempDir})
+}
+
+func (h *harness) HTTPClient() *http.Client {
+	return nil
+}
+
+func (h *harness) Close() {}
+
+func TestConformance(t *testing.T) {
+	drivertest.RunConformanceTests(t, newHarness, []drivertest.AsTest{verifyAs{}})
+}
+
+// TestOverwriteFailure checks that the payload is kept on greenfield whatever step of replacing
+// an existing object fails
+func TestOverwriteFailure(t *testing.T) {
+	ctx := context.Background()
+	h, _ := newHarness(ctx, t)
+	cli := h.(*harness).client
+	drv, err := h.MakeDriver(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := blob.NewBucket(drv)
+	defer b.Close()
+
+	if err = b.WriteAll(ctx, "a.txt", []byte("v1"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// the existing object is kept and the staging object is canceled if the payload fails to be staged
+	cli.failPut = func(objectName string) bool { return objectName != "a.txt" }
+	if err = b.WriteAll(ctx, "a.txt", []byte("version 2"), nil); err == nil {
+		t.Fatal("expect error")
+	}
+	if got, err := b.ReadAll(ctx, "a.txt"); err != nil || string(got) != "v1" {
+		t.Errorf("unexpected content %q, err: %v", got, err)
+	}
+	if names := cli.objectNames(testBucketName); len(names) != 1 {
+		t.Errorf("unexpected objects %v", names)
+	}
+
+	// the new payload is kept in the staging object if it fails to be uploaded under the key,
+	// the created object is canceled so that the key can be written again
+	cli.failPut = func(objectName string) bool { return objectName == "a.txt" }
+	if err = b.WriteAll(ctx, "a.txt", []byte("version 2"), nil); err == nil {
+		t.Fatal("expect error")
+	}
+	names := cli.objectNames(testBucketName)
+	if len(names) != 1 || !strings.HasPrefix(names[0], ".a.txt.staging-") {
+		t.Fatalf("unexpected objects %v", names)
+	}
+	cli.failPut = nil
+	if err = b.WriteAll(ctx, "a.txt", []byte("version 3"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := b.ReadAll(ctx, "a.txt"); err != nil || string(got) != "version 3" {
+		t.Errorf("unexpected content %q, err: %v", got, err)
+	}
+}
+
+// verifyAs creates the test blob as a public object and checks the visibility through As
+type verifyAs struct{}
+
+func (verifyAs) Name() string {
+	return "verify As types for gnfdblob"
+}
+
+func (verifyAs) BucketCheck(b *blob.Bucket) error {
+	var cli Client
+	if !b.As(&cli) {
+		return errors.New("Bucket.As failed")
+	}
+	return nil
+}
+
+func (verifyAs) ErrorCheck(b *blob.Bucket, err error) error {
+	if gcerrors.Code(err) != gcerrors.NotFound {
+		return fmt.Errorf("got error code %v want NotFound", gcerrors.Code(err))
+	}
+	return nil
+}
+
+func (verifyAs) BeforeRead(as func(interface{}) bool) error {
+	var opts *types.GetObjectOption
+	if !as(&opts) {
+		return errors.New("BeforeRead As failed")
+	}
+	return nil
+}
+
+func (verifyAs) BeforeWrite(as func(interface{}) bool) error {
+	var opts *types.CreateObjectOptions
+	if !as(&opts) {
+		return errors.New("BeforeWrite As failed")
+	}
+	opts.Visibility = storageTypes.VISIBILITY_TYPE_PUBLIC_READ
+	return nil
+}
+
+func (verifyAs) BeforeCopy(as func(interface{}) bool) error {
+	return nil
+}
+
+func (verifyAs) BeforeList(as func(interface{}) bool) error {
+	var opts *types.ListObjectsOptions
+	if !as(&opts) {
+		return errors.New("BeforeList As failed")
+	}
+	return nil
+}
+
+func (verifyAs) BeforeSign(as func(interface{}) bool) error {
+	return nil
+}
+
+func (verifyAs) AttributesCheck(attrs *blob.Attributes) error {
+	var info storageTypes.ObjectInfo
+	if !attrs.As(&info) {
+		return errors.New("Attributes.As returned false")
+	}
+	if info.Visibility != storageTypes.VISIBILITY_TYPE_PUBLIC_READ {
+		return fmt.Errorf("got visibility %v want %v", info.Visibility, storageTypes.VISIBILITY_TYPE_PUBLIC_READ)
+	}
+	return nil
+}
+
+func (verifyAs) ReaderCheck(r *blob.Reader) error {
+	var info storageTypes.ObjectInfo
+	if !r.As(&info) {
+		return errors.New("Reader.As returned false")
+	}
+	if info.Visibility != storageTypes.VISIBILITY_TYPE_PUBLIC_READ {
+		return fmt.Errorf("got visibility %v want %v", info.Visibility, storageTypes.VISIBILITY_TYPE_PUBLIC_READ)
+	}
+	return nil
+}
+
+func (verifyAs) ListObjectCheck(o *blob.ListObject) error {
+	if o.IsDir {
+		return nil
+	}
+	var info types.ObjectInfo
+	if !o.As(&info) {
+		return errors.New("ListObject.As returned false")
+	}
+	return nil
+}
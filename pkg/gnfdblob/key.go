@@ -0,0 +1,81 @@
+package gnfdblob
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// escapeKey escapes the runes of the blob key that greenfield does not accept in object names,
+// the escaped runes are encoded as "__0xXX__" where XX is the hex of the rune. Greenfield rejects
+// object names containing "//", the path components "." and "..", and names of spaces only.
+// Control characters are escaped as well since the object name is carried in the SP url.
+func escapeKey(key string) string {
+	key = strings.ToValidUTF8(key, string(unicode.ReplacementChar))
+	runes := []rune(key)
+	onlySpaces := strings.TrimSpace(key) == ""
+
+	var buf strings.Builder
+	for i, r := range runes {
+		if shouldEscape(runes, i, onlySpaces) {
+			fmt.Fprintf(&buf, "__%#x__", r)
+		} else {
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+func shouldEscape(runes []rune, i int, onlySpaces bool) bool {
+	r := runes[i]
+	switch {
+	case r < 32 || r == 127:
+		return true
+	case onlySpaces:
+		return true
+	// a leading slash or the second slash of "//"
+	case r == '/' && (i == 0 || runes[i-1] == '/'):
+		return true
+	case r == '.':
+		start, end := i, i
+		for start > 0 && runes[start-1] != '/' {
+			start--
+		}
+		for end < len(runes) && runes[end] != '/' {
+			end++
+		}
+		component := strings.TrimSpace(string(runes[start:end]))
+		return component == "." || component == ".."
+	}
+	return false
+}
+
+// unescapeKey reverses escapeKey
+func unescapeKey(key string) string {
+	var buf strings.Builder
+	for {
+		idx := strings.Index(key, "__0x")
+		if idx < 0 {
+			buf.WriteString(key)
+			return buf.String()
+		}
+		buf.WriteString(key[:idx])
+		key = key[idx:]
+
+		end := strings.Index(key[4:], "__")
+		if end < 0 {
+			buf.WriteString(key)
+			return buf.String()
+		}
+		r, err := strconv.ParseInt(key[4:4+end], 16, 32)
+		if err != nil {
+			// not an escaped rune, keep the "__" and go on
+			buf.WriteString(key[:2])
+			key = key[2:]
+			continue
+		}
+		buf.WriteRune(rune(r))
+		key = key[4+end+2:]
+	}
+}
@@ -0,0 +1,149 @@
+package gnfdblob
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"os"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"gocloud.dev/blob/driver"
+
+	"github.com/bnb-chain/greenfield-go-sdk/pkg/objectutil"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// attrsParam is the parameter of the stored content type the blob attributes are encoded in
+const attrsParam = "x-gnfd-blob-attrs"
+
+// blobAttrs holds the blob attributes greenfield has no place for
+type blobAttrs struct {
+	CacheControl       string            `json:"cc,omitempty"`
+	ContentDisposition string            `json:"cd,omitempty"`
+	ContentEncoding    string            `json:"ce,omitempty"`
+	ContentLanguage    string            `json:"cl,omitempty"`
+	Metadata           map[string]string `json:"md,omitempty"`
+}
+
+// encodeContentType adds the attributes to the content type as a base64 encoded json parameter
+func encodeContentType(contentType string, attrs blobAttrs) string {
+	if attrs.CacheControl == "" && attrs.ContentDisposition == "" && attrs.ContentEncoding == "" &&
+		attrs.ContentLanguage == "" && len(attrs.Metadata) == 0 {
+		return contentType
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = types.ContentDefault, map[string]string{}
+	}
+	data, err := json.Marshal(attrs)
+	if err != nil {
+		return contentType
+	}
+	params[attrsParam] = base64.RawURLEncoding.EncodeToString(data)
+	return mime.FormatMediaType(mediaType, params)
+}
+
+// decodeContentType splits the stored content type into the content type and the attributes
+func decodeContentType(stored string) (string, blobAttrs) {
+	var attrs blobAttrs
+	mediaType, params, err := mime.ParseMediaType(stored)
+	if err != nil {
+		return stored, attrs
+	}
+	encoded, ok := params[attrsParam]
+	if !ok {
+		return stored, attrs
+	}
+
+	delete(params, attrsParam)
+	if data, err := base64.RawURLEncoding.DecodeString(encoded); err == nil {
+		_ = json.Unmarshal(data, &attrs)
+	}
+	return mime.FormatMediaType(mediaType, params), attrs
+}
+
+// getETag uses the integrity hash of the primary SP as ETag, greenfield does not keep the md5 of objects
+func getETag(objectInfo *storageTypes.ObjectInfo) string {
+	if len(objectInfo.Checksums) == 0 {
+		return `"` + objectInfo.Id.String() + `"`
+	}
+	return `"` + hex.EncodeToString(objectInfo.Checksums[0]) + `"`
+}
+
+// reader implements driver.Reader
+type reader struct {
+	body       io.ReadCloser
+	attrs      driver.ReaderAttributes
+	objectInfo *storageTypes.ObjectInfo
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	return r.body.Read(p)
+}
+
+func (r *reader) Close() error {
+	return r.body.Close()
+}
+
+func (r *reader) Attributes() *driver.ReaderAttributes {
+	return &r.attrs
+}
+
+func (r *reader) As(i interface{}) bool {
+	p, ok := i.(*storageTypes.ObjectInfo)
+	if !ok {
+		return false
+	}
+	*p = *r.objectInfo
+	return true
+}
+
+// writer implements driver.Writer, the payload is spooled to a temp file since the integrity
+// hash needs the whole payload before the object is created
+type writer struct {
+	ctx        context.Context
+	bucket     *bucket
+	objectName string
+	opts       types.CreateObjectOptions
+	tmpFile    *os.File
+}
+
+func (b *bucket) newWriter(ctx context.Context, objectName string, opts types.CreateObjectOptions) (*writer, error) {
+	tmpFile, err := os.CreateTemp(b.tempDir, "gnfdblob-*")
+	if err != nil {
+		return nil, err
+	}
+	return &writer{ctx: ctx, bucket: b, objectName: objectName, opts: opts, tmpFile: tmpFile}, nil
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	return w.tmpFile.Write(p)
+}
+
+// abort drops the spooled payload without uploading
+func (w *writer) abort() {
+	w.tmpFile.Close()
+	os.Remove(w.tmpFile.Name())
+}
+
+// Close creates the object and uploads the payload, an existing object with the same name is
+// replaced through a staging object since objects on greenfield can not be overwritten, see
+// objectutil.Put
+func (w *writer) Close() error {
+	defer w.abort()
+
+	// nothing is changed if the write is canceled
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+
+	size, err := w.tmpFile.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	return objectutil.Put(w.ctx, w.bucket.client, w.bucket.name, w.objectName, w.tmpFile, size, w.opts)
+}
@@ -0,0 +1,153 @@
+// Package objectutil implements the object writes shared by the storage frontends of the SDK.
+//
+// An object is written in two steps on greenfield, it is created on chain and then the payload
+// is uploaded to the primary SP, which seals the object asynchronously. Objects can not be
+// overwritten, and only a sealed object can be deleted, an object which is created but not
+// sealed has to be canceled instead.
+package objectutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/client"
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+// removeAttempts bounds how many times Remove queries the object status again, the status can
+// change between the query and the txn when the primary SP seals the object in the meantime
+const removeAttempts = 3
+
+// Client is the subset of client.Client the object writes depend on
+type Client interface {
+	HeadObject(ctx context.Context, bucketName, objectName string) (*storageTypes.ObjectInfo, error)
+	CreateObject(ctx context.Context, bucketName, objectName string, reader io.Reader, opts types.CreateObjectOptions) (string, error)
+	PutObject(ctx context.Context, bucketName, objectName string, objectSize int64, reader io.Reader, opts types.PutObjectOptions) error
+	DeleteObject(ctx context.Context, bucketName, objectName string, opt types.DeleteObjectOption) (string, error)
+	CancelCreateObject(ctx context.Context, bucketName, objectName string, opt types.CancelCreateOption) (string, error)
+	WaitForTxSuccess(ctx context.Context, hash string) (*types.TxResult, error)
+}
+
+var _ Client = client.Client(nil)
+
+// Put writes the payload of the given size under objectName. An existing object is replaced in
+// steps that keep the payload on greenfield whatever step fails: the payload is uploaded to a
+// staging object next to it first, then the existing object is removed and the payload is
+// uploaded again under its name, and the staging object is removed at last.
+func Put(ctx context.Context, cli Client, bucketName, objectName string, payload io.ReadSeeker, size int64,
+	opts types.CreateObjectOptions,
+) error {
+	if _, err := cli.HeadObject(ctx, bucketName, objectName); err != nil {
+		if !IsNotFound(err) {
+			return err
+		}
+		return Upload(ctx, cli, bucketName, objectName, payload, size, opts)
+	}
+
+	stagingName := StagingName(objectName)
+	if err := Upload(ctx, cli, bucketName, stagingName, payload, size, opts); err != nil {
+		return err
+	}
+	if err := Remove(ctx, cli, bucketName, objectName); err != nil {
+		if removeErr := Remove(ctx, cli, bucketName, stagingName); removeErr != nil {
+			return fmt.Errorf("%w, and the staging object %s fails to be removed: %v", err, stagingName, removeErr)
+		}
+		return err
+	}
+	if err := Upload(ctx, cli, bucketName, objectName, payload, size, opts); err != nil {
+		return fmt.Errorf("replace %s failed, the payload is kept in %s: %w", objectName, stagingName, err)
+	}
+	if err := Remove(ctx, cli, bucketName, stagingName); err != nil {
+		return fmt.Errorf("%s is replaced, but the staging object %s fails to be removed: %w", objectName, stagingName, err)
+	}
+	return nil
+}
+
+// Upload creates the object and uploads the payload to the primary SP. The created object is
+// removed if the payload fails to be uploaded, otherwise it would be left unsealed and the name
+// could not be written again.
+func Upload(ctx context.Context, cli Client, bucketName, objectName string, payload io.ReadSeeker, size int64,
+	opts types.CreateObjectOptions,
+) error {
+	if _, err := payload.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	txHash, err := cli.CreateObject(ctx, bucketName, objectName, payload, opts)
+	if err != nil {
+		return err
+	}
+	if _, err = cli.WaitForTxSuccess(ctx, txHash); err != nil {
+		return err
+	}
+
+	// the empty object has no payload to upload
+	if size == 0 {
+		return nil
+	}
+	if _, err = payload.Seek(0, io.SeekStart); err == nil {
+		err = cli.PutObject(ctx, bucketName, objectName, size, payload,
+			types.PutObjectOptions{ContentType: opts.ContentType, TxnHash: txHash})
+	}
+	if err != nil {
+		if removeErr := Remove(ctx, cli, bucketName, objectName); removeErr != nil {
+			return fmt.Errorf("%w, and the created object %s fails to be removed: %v", err, objectName, removeErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// Remove deletes the object if it is sealed, or cancels it if it is created but not sealed yet
+func Remove(ctx context.Context, cli Client, bucketName, objectName string) error {
+	for attempt := 1; ; attempt++ {
+		objectInfo, err := cli.HeadObject(ctx, bucketName, objectName)
+		if err != nil {
+			return err
+		}
+
+		var txHash string
+		if objectInfo.ObjectStatus == storageTypes.OBJECT_STATUS_CREATED {
+			txHash, err = cli.CancelCreateObject(ctx, bucketName, objectName, types.CancelCreateOption{})
+		} else {
+			txHash, err = cli.DeleteObject(ctx, bucketName, objectName, types.DeleteObjectOption{})
+		}
+		if err == nil {
+			_, err = cli.WaitForTxSuccess(ctx, txHash)
+		}
+		if err == nil || attempt == removeAttempts || !isStatusChanged(err) {
+			return err
+		}
+	}
+}
+
+// StagingName returns the name of the hidden object next to objectName that Put stages the
+// replacing payload in
+func StagingName(objectName string) string {
+	dir, base := "", objectName
+	if i := strings.LastIndex(objectName, "/"); i >= 0 {
+		dir, base = objectName[:i+1], objectName[i+1:]
+	}
+	return dir + "." + base + ".staging-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// IsNotFound reports whether the error is returned by chain for a missing object or bucket, the
+// errors are transferred by grpc, so only the message can be matched
+func IsNotFound(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, storageTypes.ErrNoSuchObject.Error()) ||
+		strings.Contains(msg, storageTypes.ErrNoSuchBucket.Error())
+}
+
+// isStatusChanged reports whether the txn of Remove is rejected since the object is sealed or
+// canceled after its status is queried
+func isStatusChanged(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, storageTypes.ErrObjectNotCreated.Error()) ||
+		strings.Contains(msg, storageTypes.ErrObjectNotSealed.Error())
+}
@@ -0,0 +1,259 @@
+package objectutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+const testBucketName = "objectutil-test"
+
+type fakeObject struct {
+	info    storageTypes.ObjectInfo
+	payload []byte
+}
+
+// fakeClient keeps the objects of a single bucket in memory, the txns are executed when they are sent
+type fakeClient struct {
+	mu      sync.Mutex
+	objects map[string]*fakeObject
+	// failPut makes PutObject fail for the matched object names
+	failPut func(objectName string) bool
+	// beforeTx is called with the object name before a delete or cancel txn is executed
+	beforeTx func(objectName string)
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{objects: make(map[string]*fakeObject)}
+}
+
+func (c *fakeClient) HeadObject(ctx context.Context, bucketName, objectName string) (*storageTypes.ObjectInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, ok := c.objects[objectName]
+	if !ok {
+		return nil, fmt.Errorf("rpc error: %s", storageTypes.ErrNoSuchObject)
+	}
+	info := object.info
+	return &info, nil
+}
+
+func (c *fakeClient) CreateObject(ctx context.Context, bucketName, objectName string, reader io.Reader,
+	opts types.CreateObjectOptions,
+) (string, error) {
+	payload, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.objects[objectName]; ok {
+		return "", fmt.Errorf("rpc error: %s", storageTypes.ErrObjectAlreadyExists)
+	}
+	object := &fakeObject{info: storageTypes.ObjectInfo{
+		BucketName:   bucketName,
+		ObjectName:   objectName,
+		PayloadSize:  uint64(len(payload)),
+		ContentType:  opts.ContentType,
+		ObjectStatus: storageTypes.OBJECT_STATUS_CREATED,
+	}}
+	// the empty object is sealed once it is created
+	if len(payload) == 0 {
+		object.info.ObjectStatus = storageTypes.OBJECT_STATUS_SEALED
+	}
+	c.objects[objectName] = object
+	return "create-" + objectName, nil
+}
+
+func (c *fakeClient) PutObject(ctx context.Context, bucketName, objectName string, objectSize int64, reader io.Reader,
+	opts types.PutObjectOptions,
+) error {
+	if c.failPut != nil && c.failPut(objectName) {
+		return errors.New("put object fail")
+	}
+	payload, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, ok := c.objects[objectName]
+	if !ok {
+		return fmt.Errorf("rpc error: %s", storageTypes.ErrNoSuchObject)
+	}
+	object.payload = payload
+	object.info.ObjectStatus = storageTypes.OBJECT_STATUS_SEALED
+	return nil
+}
+
+func (c *fakeClient) DeleteObject(ctx context.Context, bucketName, objectName string, opt types.DeleteObjectOption) (string, error) {
+	if c.beforeTx != nil {
+		c.beforeTx(objectName)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, ok := c.objects[objectName]
+	if !ok {
+		return "", fmt.Errorf("rpc error: %s", storageTypes.ErrNoSuchObject)
+	}
+	if object.info.ObjectStatus != storageTypes.OBJECT_STATUS_SEALED {
+		return "", fmt.Errorf("rpc error: %s", storageTypes.ErrObjectNotSealed)
+	}
+	delete(c.objects, objectName)
+	return "delete-" + objectName, nil
+}
+
+func (c *fakeClient) CancelCreateObject(ctx context.Context, bucketName, objectName string, opt types.CancelCreateOption) (string, error) {
+	if c.beforeTx != nil {
+		c.beforeTx(objectName)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, ok := c.objects[objectName]
+	if !ok {
+		return "", fmt.Errorf("rpc error: %s", storageTypes.ErrNoSuchObject)
+	}
+	if object.info.ObjectStatus != storageTypes.OBJECT_STATUS_CREATED {
+		return "", fmt.Errorf("rpc error: %s", storageTypes.ErrObjectNotCreated)
+	}
+	delete(c.objects, objectName)
+	return "cancel-" + objectName, nil
+}
+
+func (c *fakeClient) WaitForTxSuccess(ctx context.Context, hash string) (*types.TxResult, error) {
+	return &types.TxResult{}, nil
+}
+
+func (c *fakeClient) objectNames() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var names []string
+	for name := range c.objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *fakeClient) seal(objectName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects[objectName].info.ObjectStatus = storageTypes.OBJECT_STATUS_SEALED
+}
+
+func put(t *testing.T, cli *fakeClient, objectName, payload string) error {
+	t.Helper()
+	return Put(context.Background(), cli, testBucketName, objectName, strings.NewReader(payload),
+		int64(len(payload)), types.CreateObjectOptions{})
+}
+
+func TestPut(t *testing.T) {
+	cli := newFakeClient()
+	if err := put(t, cli, "dir/a.txt", "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := put(t, cli, "dir/a.txt", "hello again"); err != nil {
+		t.Fatal(err)
+	}
+	if err := put(t, cli, "empty", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := put(t, cli, "empty", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// the staging objects are removed once the objects are replaced
+	names := cli.objectNames()
+	if len(names) != 2 || names[0] != "dir/a.txt" || names[1] != "empty" {
+		t.Fatalf("unexpected objects %v", names)
+	}
+	if got := string(cli.objects["dir/a.txt"].payload); got != "hello again" {
+		t.Errorf("got payload %q", got)
+	}
+}
+
+func TestPutUnsealed(t *testing.T) {
+	cli := newFakeClient()
+	cli.failPut = func(objectName string) bool { return true }
+	if err := put(t, cli, "a.txt", "hello"); err == nil {
+		t.Fatal("expect the upload to fail")
+	}
+	// the created object is canceled, so the name can be written again
+	if names := cli.objectNames(); len(names) != 0 {
+		t.Fatalf("unexpected objects %v", names)
+	}
+
+	// an object left unsealed by another writer is canceled when it is replaced
+	if _, err := cli.CreateObject(context.Background(), testBucketName, "a.txt", strings.NewReader("left"),
+		types.CreateObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	cli.failPut = nil
+	if err := put(t, cli, "a.txt", "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if names := cli.objectNames(); len(names) != 1 || string(cli.objects["a.txt"].payload) != "hello" {
+		t.Fatalf("unexpected objects %v", names)
+	}
+}
+
+func TestPutFailure(t *testing.T) {
+	cli := newFakeClient()
+	if err := put(t, cli, "a.txt", "old"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the existing object is untouched if the payload fails to be staged
+	cli.failPut = func(objectName string) bool { return strings.Contains(objectName, ".staging-") }
+	if err := put(t, cli, "a.txt", "new"); err == nil {
+		t.Fatal("expect the replace to fail")
+	}
+	if names := cli.objectNames(); len(names) != 1 || string(cli.objects["a.txt"].payload) != "old" {
+		t.Fatalf("unexpected objects %v", names)
+	}
+
+	// the new payload is kept in the staging object if it fails to be uploaded under the name
+	cli.failPut = func(objectName string) bool { return objectName == "a.txt" }
+	if err := put(t, cli, "a.txt", "new"); err == nil {
+		t.Fatal("expect the replace to fail")
+	}
+	names := cli.objectNames()
+	if len(names) != 1 || !strings.HasPrefix(names[0], ".a.txt.staging-") || string(cli.objects[names[0]].payload) != "new" {
+		t.Fatalf("unexpected objects %v", names)
+	}
+}
+
+func TestRemoveSealedMeanwhile(t *testing.T) {
+	cli := newFakeClient()
+	if _, err := cli.CreateObject(context.Background(), testBucketName, "a.txt", strings.NewReader("hello"),
+		types.CreateObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// the primary SP seals the object after its status is queried
+	cli.beforeTx = func(objectName string) { cli.seal(objectName) }
+	if err := Remove(context.Background(), cli, testBucketName, "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if names := cli.objectNames(); len(names) != 0 {
+		t.Fatalf("unexpected objects %v", names)
+	}
+}
+
+func TestStagingName(t *testing.T) {
+	for _, name := range []string{"a.txt", "dir/a.txt", "dir//a.txt"} {
+		staging := StagingName(name)
+		i := strings.LastIndex(name, "/")
+		if !strings.HasPrefix(staging, name[:i+1]+"."+name[i+1:]+".staging-") {
+			t.Errorf("unexpected staging name %s of %s", staging, name)
+		}
+	}
+}
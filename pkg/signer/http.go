@@ -0,0 +1,188 @@
+// Package signer provides the Signer implementations which keep the private keys out of the process.
+//
+// HTTPSigner calls a remote signing service speaking the JSON protocol below, the endpoint is the
+// url of one key of the service, such as https://signer.example.com/keys/alice:
+//
+//	GET  {endpoint}       -> {"address": "0x...", "pub_key": "<hex of the 33 bytes compressed public key>"}
+//	POST {endpoint}/sign  {"digest": "<hex of the 32 bytes digest>"} -> {"signature": "<hex of the 65 bytes signature>"}
+//
+// The errors are responded with a non 2xx status code and the body {"error": "<message>"}.
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	ctypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/evmos/ethermint/crypto/ethsecp256k1"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// KeyResponse is the response of the key request of the remote signer
+type KeyResponse struct {
+	Address string `json:"address"`
+	PubKey  string `json:"pub_key"`
+}
+
+// SignRequest is the request to sign the digest
+type SignRequest struct {
+	Digest string `json:"digest"`
+}
+
+// SignResponse is the response of the sign request
+type SignResponse struct {
+	Signature string `json:"signature"`
+}
+
+// ErrorResponse is the body of the failed requests
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// HTTPSignerOption is the optional parameters of the HTTPSigner
+type HTTPSignerOption struct {
+	// HTTPClient sends the requests to the signer, a client with 30 seconds timeout is used by default
+	HTTPClient *http.Client
+	// Header is added to all the requests, such as the Authorization header of the signing service
+	Header http.Header
+	// SignTimeout bounds each sign request since Sign takes no context, 30 seconds by default
+	SignTimeout time.Duration
+}
+
+// HTTPSigner is the Signer calling the remote signing service
+type HTTPSigner struct {
+	endpoint    string
+	httpClient  *http.Client
+	header      http.Header
+	signTimeout time.Duration
+	address     sdk.AccAddress
+	pubKey      *ethsecp256k1.PubKey
+}
+
+var _ types.Signer = (*HTTPSigner)(nil)
+
+// NewHTTPSigner fetches the public key of the key endpoint and returns the signer. It checks that
+// the address reported by the service matches the public key.
+func NewHTTPSigner(ctx context.Context, endpoint string, opt HTTPSignerOption) (*HTTPSigner, error) {
+	s := &HTTPSigner{
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		httpClient:  opt.HTTPClient,
+		header:      opt.Header,
+		signTimeout: opt.SignTimeout,
+	}
+	if s.httpClient == nil {
+		s.httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	if s.signTimeout <= 0 {
+		s.signTimeout = defaultTimeout
+	}
+
+	var keyResp KeyResponse
+	if err := s.do(ctx, http.MethodGet, s.endpoint, nil, &keyResp); err != nil {
+		return nil, err
+	}
+	pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(keyResp.PubKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key %s of the signer: %w", keyResp.PubKey, err)
+	}
+	if _, err = crypto.DecompressPubkey(pubKeyBytes); err != nil {
+		return nil, fmt.Errorf("invalid public key %s of the signer: %w", keyResp.PubKey, err)
+	}
+	s.pubKey = &ethsecp256k1.PubKey{Key: pubKeyBytes}
+	s.address = sdk.AccAddress(s.pubKey.Address())
+
+	if keyResp.Address != "" && !strings.EqualFold(keyResp.Address, s.address.String()) {
+		return nil, fmt.Errorf("the address %s of the signer does not match its public key %s",
+			keyResp.Address, s.address.String())
+	}
+	return s, nil
+}
+
+// GetAddress returns the address of the remote key
+func (s *HTTPSigner) GetAddress() sdk.AccAddress {
+	return s.address
+}
+
+// PubKey returns the public key of the remote key
+func (s *HTTPSigner) PubKey() ctypes.PubKey {
+	return s.pubKey
+}
+
+// Sign sends the digest of the msg to the signing service and verifies the returned signature, the request fails
+// if the service does not respond within the sign timeout
+func (s *HTTPSigner) Sign(msg []byte) ([]byte, error) {
+	digest := msg
+	if len(digest) != crypto.DigestLength {
+		digest = crypto.Keccak256(msg)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.signTimeout)
+	defer cancel()
+	var signResp SignResponse
+	err := s.do(ctx, http.MethodPost, s.endpoint+"/sign",
+		SignRequest{Digest: hex.EncodeToString(digest)}, &signResp)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(signResp.Signature, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature %s of the signer: %w", signResp.Signature, err)
+	}
+	if len(signature) != crypto.SignatureLength ||
+		!crypto.VerifySignature(s.pubKey.Key, digest, signature[:crypto.RecoveryIDOffset]) {
+		return nil, errors.New("the signature of the signer is invalid")
+	}
+	return signature, nil
+}
+
+func (s *HTTPSigner) do(ctx context.Context, method, url string, reqBody, respBody interface{}) error {
+	var body io.Reader
+	if reqBody != nil {
+		data, err := json.Marshal(reqBody)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	for key, values := range s.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errResp ErrorResponse
+		if err = json.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Error == "" {
+			return fmt.Errorf("signer responded %s", resp.Status)
+		}
+		return fmt.Errorf("signer responded %s: %s", resp.Status, errResp.Error)
+	}
+	return json.NewDecoder(resp.Body).Decode(respBody)
+}
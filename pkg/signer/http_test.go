@@ -0,0 +1,142 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/greenfield-go-sdk/types"
+)
+
+const testPrivateKey = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+
+// standInSigner is a local stand-in of the remote signing service
+type standInSigner struct {
+	key     *ecdsa.PrivateKey
+	token   string
+	address string
+	corrupt bool
+	// delay holds the sign responses
+	delay time.Duration
+}
+
+func (s *standInSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "invalid token"})
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/keys/alice":
+		address := s.address
+		if address == "" {
+			address = crypto.PubkeyToAddress(s.key.PublicKey).Hex()
+		}
+		_ = json.NewEncoder(w).Encode(KeyResponse{
+			Address: address,
+			PubKey:  hex.EncodeToString(crypto.CompressPubkey(&s.key.PublicKey)),
+		})
+	case r.Method == http.MethodPost && r.URL.Path == "/keys/alice/sign":
+		time.Sleep(s.delay)
+		var req SignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		digest, err := hex.DecodeString(req.Digest)
+		if err != nil || len(digest) != crypto.DigestLength {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{Error: "invalid digest"})
+			return
+		}
+		signature, err := crypto.Sign(digest, s.key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if s.corrupt {
+			signature[0] ^= 0xff
+		}
+		_ = json.NewEncoder(w).Encode(SignResponse{Signature: hex.EncodeToString(signature)})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestSigner(t *testing.T, standIn *standInSigner) (*HTTPSigner, error) {
+	return newTestSignerWithTimeout(t, standIn, 0)
+}
+
+func newTestSignerWithTimeout(t *testing.T, standIn *standInSigner, signTimeout time.Duration) (*HTTPSigner, error) {
+	server := httptest.NewServer(standIn)
+	t.Cleanup(server.Close)
+	return NewHTTPSigner(context.Background(), server.URL+"/keys/alice", HTTPSignerOption{
+		Header:      http.Header{"Authorization": []string{"Bearer secret"}},
+		SignTimeout: signTimeout,
+	})
+}
+
+func TestHTTPSigner(t *testing.T) {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	require.NoError(t, err)
+	local, err := types.NewAccountFromPrivateKey("local", testPrivateKey)
+	require.NoError(t, err)
+
+	signer, err := newTestSigner(t, &standInSigner{key: key, token: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, local.GetAddress(), signer.GetAddress())
+	assert.True(t, local.PubKey().Equals(signer.PubKey()))
+
+	remote, err := types.NewAccountFromSigner("remote", signer)
+	require.NoError(t, err)
+	assert.Equal(t, local.GetAddress(), remote.GetAddress())
+
+	// the signatures are deterministic, so the remote ones must be the same as the local ones
+	for _, msg := range [][]byte{[]byte("the msg to sign"), crypto.Keccak256([]byte("a digest"))} {
+		expected, err := local.Sign(msg)
+		require.NoError(t, err)
+
+		signature, err := remote.Sign(msg)
+		require.NoError(t, err)
+		assert.Equal(t, expected, signature)
+
+		signature, err = remote.GetKeyManager().Sign(msg)
+		require.NoError(t, err)
+		assert.Equal(t, expected, signature)
+	}
+
+	_, err = remote.ExportKeystore("secret")
+	assert.ErrorIs(t, err, types.ErrorPrivateKeyNotAvailable)
+	assert.Nil(t, remote.GetKeyManager().Bytes())
+}
+
+func TestHTTPSignerErrors(t *testing.T) {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	require.NoError(t, err)
+
+	_, err = newTestSigner(t, &standInSigner{key: key, token: "another"})
+	assert.ErrorContains(t, err, "invalid token")
+
+	_, err = newTestSigner(t, &standInSigner{key: key, token: "secret", address: "0x45DeA0FB0bBA44f4fcF290bbA71Fd57d7117Cbb8"})
+	assert.ErrorContains(t, err, "does not match")
+
+	signer, err := newTestSigner(t, &standInSigner{key: key, token: "secret", corrupt: true})
+	require.NoError(t, err)
+	_, err = signer.Sign([]byte("the msg to sign"))
+	assert.ErrorContains(t, err, "signature of the signer is invalid")
+
+	signer, err = newTestSignerWithTimeout(t, &standInSigner{key: key, token: "secret", delay: 300 * time.Millisecond}, 20*time.Millisecond)
+	require.NoError(t, err)
+	_, err = signer.Sign([]byte("the msg to sign"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
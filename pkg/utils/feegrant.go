@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+// NewBasicAllowance returns the allowance of the fees up to the spend limit in BNB wei, the limit is unlimited if
+// it is nil. The allowance expires at the expiration, or never if it is nil.
+func NewBasicAllowance(spendLimit *sdkmath.Int, expiration *time.Time) *feegrant.BasicAllowance {
+	allowance := &feegrant.BasicAllowance{Expiration: expiration}
+	if spendLimit != nil {
+		allowance.SpendLimit = sdk.NewCoins(sdk.NewCoin(gnfdSdkTypes.Denom, *spendLimit))
+	}
+	return allowance
+}
+
+// NewPeriodicAllowance returns the allowance of the fees up to the period spend limit in BNB wei in every period,
+// which starts now, on top of the basic allowance
+func NewPeriodicAllowance(basic *feegrant.BasicAllowance, period time.Duration, periodSpendLimit sdkmath.Int) *feegrant.PeriodicAllowance {
+	periodLimit := sdk.NewCoins(sdk.NewCoin(gnfdSdkTypes.Denom, periodSpendLimit))
+	return &feegrant.PeriodicAllowance{
+		Basic:            *basic,
+		Period:           period,
+		PeriodSpendLimit: periodLimit,
+		PeriodCanSpend:   periodLimit,
+		PeriodReset:      time.Now().Add(period),
+	}
+}
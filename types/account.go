@@ -1,19 +1,29 @@
 package types
 
 import (
-	"cosmossdk.io/math"
 	"encoding/hex"
 
+	"cosmossdk.io/math"
 	"github.com/bnb-chain/greenfield/sdk/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	ctypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/go-bip39"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
-// TODO: Will add an AccountRegister struct to manage multi account.
+const (
+	// ethCoinType is the BIP44 coin type of Ethereum, greenfield uses the Ethereum compatible keys
+	ethCoinType = 60
+	// mnemonicEntropySize is the entropy bits of the 24 words mnemonic
+	mnemonicEntropySize = 256
+)
 
 type Account struct {
 	name string
 	km   keys.KeyManager
+	// privKey is the raw private key, it is kept for exporting the account
+	privKey []byte
 }
 
 type TransferDetail struct {
@@ -26,9 +36,14 @@ func NewAccountFromPrivateKey(name, privKey string) (*Account, error) {
 	if err != nil {
 		return nil, err
 	}
+	privKeyBytes, err := hex.DecodeString(privKey)
+	if err != nil {
+		return nil, err
+	}
 	return &Account{
-		name: name,
-		km:   km,
+		name:    name,
+		km:      km,
+		privKey: privKeyBytes,
 	}, nil
 }
 
@@ -37,14 +52,68 @@ func NewAccountFromMnemonic(name, mnemonic string) (*Account, error) {
 	if err != nil {
 		return nil, err
 	}
+	privKey, err := derivePrivateKey(mnemonic, keys.FullPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		name:    name,
+		km:      km,
+		privKey: privKey,
+	}, nil
+}
+
+// NewAccountFromMnemonicWithPath derives the account of the BIP44 hd path like m/44'/60'/0'/0/0 from the mnemonic
+func NewAccountFromMnemonicWithPath(name, mnemonic, hdPath string) (*Account, error) {
+	if _, err := hd.NewParamsFromPath(hdPath); err != nil {
+		return nil, err
+	}
+	privKey, err := derivePrivateKey(mnemonic, hdPath)
+	if err != nil {
+		return nil, err
+	}
+	km, err := keys.NewPrivateKeyManager(hex.EncodeToString(privKey))
+	if err != nil {
+		return nil, err
+	}
 	return &Account{
-		name: name,
-		km:   km,
+		name:    name,
+		km:      km,
+		privKey: privKey,
 	}, nil
 }
 
-// TODO: return mnemonic to user
+// NewAccountFromMnemonicWithIndex derives the account of the path m/44'/60'/account'/0/index from the mnemonic,
+// different indexes give the sub accounts of the same mnemonic
+func NewAccountFromMnemonicWithIndex(name, mnemonic string, account, index uint32) (*Account, error) {
+	return NewAccountFromMnemonicWithPath(name, mnemonic, HDPath(account, index))
+}
+
+// HDPath returns the BIP44 hd path m/44'/60'/account'/0/index of the Ethereum coin type used by greenfield
+func HDPath(account, index uint32) string {
+	return hd.CreateHDPath(ethCoinType, account, index).String()
+}
 
+// NewAccountWithMnemonic generates a 24 words BIP39 mnemonic and returns the account of the default hd path
+// together with the mnemonic, which should be backed up by the user to recover the account
+func NewAccountWithMnemonic(name string) (*Account, string, error) {
+	entropy, err := bip39.NewEntropy(mnemonicEntropySize)
+	if err != nil {
+		return nil, "", err
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return nil, "", err
+	}
+	account, err := NewAccountFromMnemonic(name, mnemonic)
+	if err != nil {
+		return nil, "", err
+	}
+	return account, mnemonic, nil
+}
+
+// NewAccount generates a random private key and returns the account together with the hex private key,
+// use NewAccountWithMnemonic to get a recoverable mnemonic instead
 func NewAccount(name string) (*Account, string, error) {
 	privKey := secp256k1.GenPrivKey()
 	km, err := keys.NewPrivateKeyManager(hex.EncodeToString(privKey.Bytes()))
@@ -52,11 +121,16 @@ func NewAccount(name string) (*Account, string, error) {
 		return nil, "", err
 	}
 	return &Account{
-		name: name,
-		km:   km,
+		name:    name,
+		km:      km,
+		privKey: privKey.Bytes(),
 	}, hex.EncodeToString(privKey.Bytes()), nil
 }
 
+func (a *Account) GetName() string {
+	return a.name
+}
+
 func (a *Account) GetKeyManager() keys.KeyManager {
 	return a.km
 }
@@ -65,6 +139,24 @@ func (a *Account) GetAddress() sdk.AccAddress {
 	return a.km.GetAddr()
 }
 
+func (a *Account) PubKey() ctypes.PubKey {
+	return a.km.PubKey()
+}
+
 func (a *Account) Sign(unsignBytes []byte) ([]byte, error) {
 	return a.km.Sign(unsignBytes)
 }
+
+// derivePrivateKey derives the private key of the hd path from the mnemonic, in the same way as the key manager
+func derivePrivateKey(mnemonic, hdPath string) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	masterPriv, ch := hd.ComputeMastersFromSeed(seed)
+	derivedPriv, err := hd.DerivePrivateKeyForPath(masterPriv, ch, hdPath)
+	if err != nil {
+		return nil, err
+	}
+	return derivedPriv[:], nil
+}
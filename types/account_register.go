@@ -0,0 +1,75 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// AccountRegister is a thread-safe keyring which manages multi accounts by name
+type AccountRegister struct {
+	mu       sync.RWMutex
+	accounts map[string]*Account
+}
+
+// NewAccountRegister returns an AccountRegister containing the accounts
+func NewAccountRegister(accounts ...*Account) (*AccountRegister, error) {
+	r := &AccountRegister{accounts: make(map[string]*Account, len(accounts))}
+	for _, account := range accounts {
+		if err := r.Add(account); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Add registers the account, the name of the account must be unique in the register
+func (r *AccountRegister) Add(account *Account) error {
+	if account == nil {
+		return ErrorAccountIsNil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.accounts[account.name]; ok {
+		return fmt.Errorf("account %s already exists", account.name)
+	}
+	r.accounts[account.name] = account
+	return nil
+}
+
+// Get returns the account of the name
+func (r *AccountRegister) Get(name string) (*Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	account, ok := r.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("account %s not found", name)
+	}
+	return account, nil
+}
+
+// Remove deletes the account of the name from the register, it returns false if the account not exists
+func (r *AccountRegister) Remove(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.accounts[name]; !ok {
+		return false
+	}
+	delete(r.accounts, name)
+	return true
+}
+
+// List returns all the accounts of the register sorted by name
+func (r *AccountRegister) List() []*Account {
+	r.mu.RLock()
+	accounts := make([]*Account, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		accounts = append(accounts, account)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].name < accounts[j].name
+	})
+	return accounts
+}
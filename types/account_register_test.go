@@ -0,0 +1,56 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountRegister(t *testing.T) {
+	alice, _, err := NewAccount("alice")
+	require.NoError(t, err)
+	bob, _, err := NewAccount("bob")
+	require.NoError(t, err)
+
+	r, err := NewAccountRegister(bob, alice)
+	require.NoError(t, err)
+
+	account, err := r.Get("alice")
+	require.NoError(t, err)
+	assert.Equal(t, alice.GetAddress(), account.GetAddress())
+	assert.Equal(t, []*Account{alice, bob}, r.List())
+
+	another, _, err := NewAccount("alice")
+	require.NoError(t, err)
+	assert.Error(t, r.Add(another))
+	assert.ErrorIs(t, r.Add(nil), ErrorAccountIsNil)
+
+	assert.True(t, r.Remove("alice"))
+	assert.False(t, r.Remove("alice"))
+	_, err = r.Get("alice")
+	assert.Error(t, err)
+}
+
+func TestAccountRegisterConcurrent(t *testing.T) {
+	r, err := NewAccountRegister()
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			account, _, err := NewAccount(fmt.Sprintf("account-%d", i))
+			assert.NoError(t, err)
+			assert.NoError(t, r.Add(account))
+			_, err = r.Get(account.GetName())
+			assert.NoError(t, err)
+			r.List()
+		}(i)
+	}
+	wg.Wait()
+	assert.Len(t, r.List(), 16)
+}
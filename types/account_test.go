@@ -0,0 +1,56 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the well known mnemonic of the hardhat and foundry test accounts
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func TestNewAccountFromMnemonicWithIndex(t *testing.T) {
+	tests := []struct {
+		account uint32
+		index   uint32
+		path    string
+		address string
+	}{
+		{account: 0, index: 0, path: "m/44'/60'/0'/0/0", address: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+		{account: 0, index: 1, path: "m/44'/60'/0'/0/1", address: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+		{account: 0, index: 2, path: "m/44'/60'/0'/0/2", address: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.path, HDPath(tt.account, tt.index))
+
+		account, err := NewAccountFromMnemonicWithIndex("test", testMnemonic, tt.account, tt.index)
+		require.NoError(t, err)
+		assert.Equal(t, tt.address, account.GetAddress().String())
+
+		account, err = NewAccountFromMnemonicWithPath("test", testMnemonic, tt.path)
+		require.NoError(t, err)
+		assert.Equal(t, tt.address, account.GetAddress().String())
+	}
+
+	// the default path is the same as the key manager
+	account, err := NewAccountFromMnemonic("test", testMnemonic)
+	require.NoError(t, err)
+	assert.Equal(t, tests[0].address, account.GetAddress().String())
+
+	_, err = NewAccountFromMnemonicWithPath("test", testMnemonic, "m/44'/60'")
+	assert.Error(t, err)
+	_, err = NewAccountFromMnemonicWithPath("test", "test junk", HDPath(0, 0))
+	assert.Error(t, err)
+}
+
+func TestNewAccountWithMnemonic(t *testing.T) {
+	account, mnemonic, err := NewAccountWithMnemonic("test")
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(mnemonic), 24)
+
+	recovered, err := NewAccountFromMnemonic("test", mnemonic)
+	require.NoError(t, err)
+	assert.Equal(t, account.GetAddress(), recovered.GetAddress())
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// DefaultBatchMaxBytesPerTx keeps the txs of a batch below the default max tx size of the mempool
+const DefaultBatchMaxBytesPerTx = 1000 * 1024
+
+// BatchMsgResult is the result of a msg sent by a TxBatch
+type BatchMsgResult struct {
+	Msg    sdk.Msg
+	TxHash string
+	Height int64
+	// Response is the response of the msg, such as *storageTypes.MsgCreateBucketResponse carrying the bucket id,
+	// it is nil if the response type of the msg is unknown
+	Response proto.Message
+	// Events are the events emitted by the msg
+	Events []abci.Event
+}
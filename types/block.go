@@ -0,0 +1,126 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// DecodedTx is a tx decoded from its bytes with the msgs of greenfield unpacked
+type DecodedTx struct {
+	// Hash is the HEX-encoded hash of the tx bytes, which is the hash the tx is queried by
+	Hash string
+	// Signers are the accounts signing the tx, in the order of the msgs, followed by the fee payer
+	Signers    []sdk.AccAddress
+	FeePayer   sdk.AccAddress
+	FeeGranter sdk.AccAddress
+	Fee        sdk.Coins
+	GasLimit   uint64
+	Memo       string
+	Msgs       []sdk.Msg
+	// Tx is the decoded tx, which carries the signatures and the other fields not listed above
+	Tx *tx.Tx
+}
+
+// DecodeTx decodes the tx bytes, such as the ones of a block or the ones returned by SignTx
+func DecodeTx(txBytes []byte) (*DecodedTx, error) {
+	decodedTx := &tx.Tx{}
+	if err := decodedTx.Unmarshal(txBytes); err != nil {
+		return nil, err
+	}
+	if err := decodedTx.UnpackInterfaces(gnfdSdkTypes.Codec().InterfaceRegistry()); err != nil {
+		return nil, err
+	}
+	if decodedTx.Body == nil || decodedTx.AuthInfo == nil || decodedTx.AuthInfo.Fee == nil {
+		return nil, errors.New("the tx misses its body or fee")
+	}
+
+	fee := decodedTx.AuthInfo.Fee
+	result := &DecodedTx{
+		Hash:     fmt.Sprintf("%X", tmtypes.Tx(txBytes).Hash()),
+		Fee:      fee.Amount,
+		GasLimit: fee.GasLimit,
+		Memo:     decodedTx.Body.Memo,
+		Msgs:     decodedTx.GetMsgs(),
+		Tx:       decodedTx,
+	}
+	seen := make(map[string]bool)
+	for _, msg := range result.Msgs {
+		signers, err := msgSigners(msg)
+		if err != nil {
+			return nil, err
+		}
+		for _, signer := range signers {
+			if !seen[signer.String()] {
+				seen[signer.String()] = true
+				result.Signers = append(result.Signers, signer)
+			}
+		}
+	}
+	if fee.Payer != "" {
+		payer, err := sdk.AccAddressFromHexUnsafe(fee.Payer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee payer of the tx: %w", err)
+		}
+		result.FeePayer = payer
+		if !seen[payer.String()] {
+			result.Signers = append(result.Signers, payer)
+		}
+	} else if len(result.Signers) > 0 {
+		result.FeePayer = result.Signers[0]
+	}
+	if fee.Granter != "" {
+		granter, err := sdk.AccAddressFromHexUnsafe(fee.Granter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee granter of the tx: %w", err)
+		}
+		result.FeeGranter = granter
+	}
+	return result, nil
+}
+
+// msgSigners returns the signers of the msg, GetSigners of the msgs panics if a signer is invalid
+func msgSigners(msg sdk.Msg) (signers []sdk.AccAddress, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid signer of the msg %s: %v", sdk.MsgTypeURL(msg), r)
+		}
+	}()
+	return msg.GetSigners(), nil
+}
+
+// DecodedBlock is a block with its txs decoded
+type DecodedBlock struct {
+	Height int64
+	Time   time.Time
+	// ProposerAddress is the address of the consensus key of the validator proposing the block
+	ProposerAddress string
+	Txs             []*DecodedTx
+}
+
+// DecodeBlock decodes the txs of the block returned by GetLatestBlock or GetBlockByHeight
+func DecodeBlock(block *tmservice.Block) (*DecodedBlock, error) {
+	if block == nil {
+		return nil, errors.New("the block is nil")
+	}
+	result := &DecodedBlock{
+		Height:          block.Header.Height,
+		Time:            block.Header.Time,
+		ProposerAddress: block.Header.ProposerAddress,
+		Txs:             make([]*DecodedTx, 0, len(block.Data.Txs)),
+	}
+	for i, txBytes := range block.Data.Txs {
+		decodedTx, err := DecodeTx(txBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode tx %d of block %d: %w", i, result.Height, err)
+		}
+		result.Txs = append(result.Txs, decodedTx)
+	}
+	return result, nil
+}
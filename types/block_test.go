@@ -0,0 +1,99 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	gnfdsdktypes "github.com/bnb-chain/greenfield/sdk/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func newTestTxBytes(t *testing.T, payer, granter string) ([]byte, *bankTypes.MsgSend) {
+	alice, _, err := NewAccount("alice")
+	require.NoError(t, err)
+	bob, _, err := NewAccount("bob")
+	require.NoError(t, err)
+	msg := bankTypes.NewMsgSend(alice.GetAddress(), bob.GetAddress(), sdk.NewCoins(sdk.NewInt64Coin(gnfdsdktypes.Denom, 100)))
+	msgAny, err := codectypes.NewAnyWithValue(msg)
+	require.NoError(t, err)
+	txBytes, err := (&tx.Tx{
+		Body: &tx.TxBody{Messages: []*codectypes.Any{msgAny}, Memo: "memo"},
+		AuthInfo: &tx.AuthInfo{Fee: &tx.Fee{
+			Amount:   sdk.NewCoins(sdk.NewInt64Coin(gnfdsdktypes.Denom, 1200)),
+			GasLimit: 1200,
+			Payer:    payer,
+			Granter:  granter,
+		}},
+	}).Marshal()
+	require.NoError(t, err)
+	return txBytes, msg
+}
+
+func TestDecodeTx(t *testing.T) {
+	txBytes, msg := newTestTxBytes(t, "", "")
+	decodedTx, err := DecodeTx(txBytes)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%X", tmtypes.Tx(txBytes).Hash()), decodedTx.Hash)
+	assert.Equal(t, "memo", decodedTx.Memo)
+	assert.Equal(t, uint64(1200), decodedTx.GasLimit)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(gnfdsdktypes.Denom, 1200)), decodedTx.Fee)
+	require.Len(t, decodedTx.Msgs, 1)
+	decodedMsg, ok := decodedTx.Msgs[0].(*bankTypes.MsgSend)
+	require.True(t, ok)
+	assert.Equal(t, msg, decodedMsg)
+	assert.Equal(t, []sdk.AccAddress{sdk.MustAccAddressFromHex(msg.FromAddress)}, decodedTx.Signers)
+	assert.Equal(t, sdk.MustAccAddressFromHex(msg.FromAddress), decodedTx.FeePayer)
+	assert.Nil(t, decodedTx.FeeGranter)
+
+	// the fee payer other than the sender signs the tx as well
+	payer, _, err := NewAccount("payer")
+	require.NoError(t, err)
+	txBytes, msg = newTestTxBytes(t, payer.GetAddress().String(), payer.GetAddress().String())
+	decodedTx, err = DecodeTx(txBytes)
+	require.NoError(t, err)
+	assert.Equal(t, []sdk.AccAddress{sdk.MustAccAddressFromHex(msg.FromAddress), payer.GetAddress()}, decodedTx.Signers)
+	assert.Equal(t, payer.GetAddress(), decodedTx.FeePayer)
+	assert.Equal(t, payer.GetAddress(), decodedTx.FeeGranter)
+
+	_, err = DecodeTx([]byte("invalid"))
+	assert.Error(t, err)
+	txBytes, _ = newTestTxBytes(t, "invalid", "")
+	_, err = DecodeTx(txBytes)
+	assert.Error(t, err)
+	// the msg without a valid signer
+	msgAny, err := codectypes.NewAnyWithValue(&storageTypes.MsgCreateBucket{})
+	require.NoError(t, err)
+	txBytes, err = (&tx.Tx{Body: &tx.TxBody{Messages: []*codectypes.Any{msgAny}}, AuthInfo: &tx.AuthInfo{Fee: &tx.Fee{}}}).Marshal()
+	require.NoError(t, err)
+	_, err = DecodeTx(txBytes)
+	assert.Error(t, err)
+}
+
+func TestDecodeBlock(t *testing.T) {
+	txBytes, _ := newTestTxBytes(t, "", "")
+	block := &tmservice.Block{
+		Header: tmservice.Header{Height: 10, ProposerAddress: "proposer"},
+		Data:   tmproto.Data{Txs: [][]byte{txBytes, txBytes}},
+	}
+	decodedBlock, err := DecodeBlock(block)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), decodedBlock.Height)
+	assert.Equal(t, "proposer", decodedBlock.ProposerAddress)
+	require.Len(t, decodedBlock.Txs, 2)
+	assert.Equal(t, "memo", decodedBlock.Txs[1].Memo)
+
+	block.Data.Txs = append(block.Data.Txs, []byte("invalid"))
+	_, err = DecodeBlock(block)
+	assert.Error(t, err)
+	_, err = DecodeBlock(nil)
+	assert.Error(t, err)
+}
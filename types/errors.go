@@ -14,6 +14,13 @@ const unknownErr = "unknown error"
 var (
 	ErrorDefaultAccountNotExist = errors.New("Default account of client is not exist ")
 	ErrorProposalIDNotFound     = errors.New("Proposal ID not found ")
+	ErrorAccountIsNil           = errors.New("Account is nil ")
+	ErrorPrivateKeyNotAvailable = errors.New("Private key of the account is not available ")
+	ErrorSignerIsNil            = errors.New("Signer is nil ")
+	ErrorWatchOnlyAccount       = errors.New("Watch-only account can't sign ")
+	// ErrMultisigNotSupported is returned for the txs signed by a multisig account, the greenfield chain
+	// rejects any multi signature until it is upgraded to verify them
+	ErrMultisigNotSupported = errors.New("multi signature txs are not supported by the greenfield chain")
 )
 
 // ErrResponse define the information of the error response
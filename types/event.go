@@ -0,0 +1,52 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// greenfieldEventPrefix is the prefix of the names of the typed events of the greenfield modules
+const greenfieldEventPrefix = "bnbchain.greenfield."
+
+// EventFilter indicates the chain events to subscribe
+type EventFilter struct {
+	// EventTypes are the names of the typed events to receive, such as
+	// proto.MessageName(&storageTypes.EventCreateBucket{}). All the typed events of the greenfield modules are
+	// received if it is empty.
+	EventTypes []string
+	// FromHeight is the height of the first block whose events are received, the events of the blocks committed
+	// before the subscription are replayed first. Only the events of the new blocks are received if it is zero.
+	FromHeight int64
+	// BufferSize is the capacity of the channel of the events, DefaultEventBufferSize by default
+	BufferSize int
+}
+
+// DefaultEventBufferSize is the default capacity of the channel of the subscribed events
+const DefaultEventBufferSize = 100
+
+// Match returns whether the event of the type passes the filter
+func (f EventFilter) Match(eventType string) bool {
+	if len(f.EventTypes) == 0 {
+		return strings.HasPrefix(eventType, greenfieldEventPrefix)
+	}
+	for _, t := range f.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// ChainEvent is a typed event emitted by chain
+type ChainEvent struct {
+	Height int64
+	// TxHash is the hash of the tx emitting the event, it is empty for the events emitted at the beginning or the
+	// end of the block, such as the stream records settled by the payment module
+	TxHash string
+	// Event is the typed event, such as *storageTypes.EventSealObject
+	Event proto.Message
+	// Err is set if the subscription stops on an error, it is carried by the last value of the channel, whose
+	// Height is of the block failing to be fetched and whose TxHash and Event are empty
+	Err error
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"fmt"
+	"math"
+
+	gnfdsdktypes "github.com/bnb-chain/greenfield/sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GasPolicy indicates how the gas limit and the fee of a tx are derived from its simulation
+type GasPolicy struct {
+	// GasMultiplier scales the simulated gas to leave room for the state changed before the tx is executed,
+	// a multiplier below 1 is taken as 1
+	GasMultiplier float64
+	// MaxGas caps the gas limit, the gas is unlimited if it is zero
+	MaxGas uint64
+	// GasPrice is the fixed gas price, the min gas price reported by the simulation of the node is used if it is nil
+	GasPrice *sdk.Coin
+}
+
+// GasEstimate is the gas and the fee of a tx derived from its simulation
+type GasEstimate struct {
+	// GasUsed is the gas used by the simulation
+	GasUsed  uint64
+	GasLimit uint64
+	GasPrice sdk.Coin
+	// Fee is the gas price times the gas limit, which is paid for the tx
+	Fee sdk.Coins
+}
+
+// Estimate derives the gas limit and the fee from the simulated gas info, a nil policy uses the simulated gas
+// and the min gas price as they are
+func (p *GasPolicy) Estimate(gasInfo *sdk.GasInfo) (*GasEstimate, error) {
+	if gasInfo == nil {
+		return nil, fmt.Errorf("no gas info is simulated")
+	}
+	policy := GasPolicy{}
+	if p != nil {
+		policy = *p
+	}
+
+	gasLimit := gasInfo.GasUsed
+	if policy.GasMultiplier > 1 {
+		gasLimit = uint64(math.Ceil(float64(gasInfo.GasUsed) * policy.GasMultiplier))
+	}
+	if policy.MaxGas != 0 && gasLimit > policy.MaxGas {
+		if gasInfo.GasUsed > policy.MaxGas {
+			return nil, fmt.Errorf("the simulated gas %d exceeds the max gas %d", gasInfo.GasUsed, policy.MaxGas)
+		}
+		gasLimit = policy.MaxGas
+	}
+
+	var gasPrice sdk.Coin
+	if policy.GasPrice != nil {
+		gasPrice = *policy.GasPrice
+	} else {
+		var err error
+		if gasPrice, err = sdk.ParseCoinNormalized(gasInfo.MinGasPrice); err != nil {
+			return nil, err
+		}
+	}
+	if gasPrice.IsNil() || gasPrice.IsZero() {
+		return nil, gnfdsdktypes.SimulatedGasPriceError
+	}
+
+	return &GasEstimate{
+		GasUsed:  gasInfo.GasUsed,
+		GasLimit: gasLimit,
+		GasPrice: gasPrice,
+		Fee:      sdk.NewCoins(sdk.NewCoin(gasPrice.Denom, gasPrice.Amount.Mul(sdk.NewIntFromUint64(gasLimit)))),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	"testing"
+
+	gnfdsdktypes "github.com/bnb-chain/greenfield/sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasPolicyEstimate(t *testing.T) {
+	gasInfo := &sdk.GasInfo{GasUsed: 1000, MinGasPrice: "5BNB"}
+
+	var policy *GasPolicy
+	estimate, err := policy.Estimate(gasInfo)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), estimate.GasLimit)
+	assert.Equal(t, sdk.NewInt64Coin(gnfdsdktypes.Denom, 5), estimate.GasPrice)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(gnfdsdktypes.Denom, 5000)), estimate.Fee)
+
+	policy = &GasPolicy{GasMultiplier: 1.25}
+	estimate, err = policy.Estimate(gasInfo)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), estimate.GasUsed)
+	assert.Equal(t, uint64(1250), estimate.GasLimit)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(gnfdsdktypes.Denom, 6250)), estimate.Fee)
+
+	// the multiplied gas is capped, but the simulated gas itself can't exceed the cap
+	policy = &GasPolicy{GasMultiplier: 2, MaxGas: 1500}
+	estimate, err = policy.Estimate(gasInfo)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1500), estimate.GasLimit)
+	policy = &GasPolicy{MaxGas: 999}
+	_, err = policy.Estimate(gasInfo)
+	assert.Error(t, err)
+
+	gasPrice := sdk.NewInt64Coin(gnfdsdktypes.Denom, 7)
+	policy = &GasPolicy{GasPrice: &gasPrice}
+	estimate, err = policy.Estimate(gasInfo)
+	require.NoError(t, err)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(gnfdsdktypes.Denom, 7000)), estimate.Fee)
+
+	_, err = (&GasPolicy{}).Estimate(&sdk.GasInfo{GasUsed: 1000, MinGasPrice: "0BNB"})
+	assert.ErrorIs(t, err, gnfdsdktypes.SimulatedGasPriceError)
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// NewAccountFromKeystore decrypts the Ethereum keystore v3 file with the password and returns the account.
+// The account is named after the file name without the extension.
+func NewAccountFromKeystore(path, password string) (*Account, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return NewAccountFromKeystoreJSON(name, keyJSON, password)
+}
+
+// NewAccountFromKeystoreJSON decrypts the Ethereum keystore v3 json with the password and returns the account
+func NewAccountFromKeystoreJSON(name string, keyJSON []byte, password string) (*Account, error) {
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccountFromPrivateKey(name, hex.EncodeToString(crypto.FromECDSA(key.PrivateKey)))
+}
+
+// ExportKeystore encrypts the private key of the account with the password into the Ethereum keystore v3 json,
+// the key is derived by scrypt with the standard parameters.
+func (a *Account) ExportKeystore(password string) ([]byte, error) {
+	return a.exportKeystore(password, keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
+func (a *Account) exportKeystore(password string, scryptN, scryptP int) ([]byte, error) {
+	if len(a.privKey) == 0 {
+		return nil, ErrorPrivateKeyNotAvailable
+	}
+	privKey, err := crypto.ToECDSA(a.privKey)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	key := &keystore.Key{
+		Id:         id,
+		Address:    common.BytesToAddress(a.GetAddress()),
+		PrivateKey: privKey,
+	}
+	return keystore.EncryptKey(key, password, scryptN, scryptP)
+}
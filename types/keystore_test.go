@@ -0,0 +1,78 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the vectors are from https://github.com/ethereum/wiki/wiki/Web3-Secret-Storage-Definition
+var keystoreVectors = []struct {
+	name     string
+	json     string
+	password string
+	address  string
+}{
+	{
+		name:     "scrypt",
+		json:     `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},"ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c","kdf":"scrypt","kdfparams":{"dklen":32,"n":262144,"r":1,"p":8,"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`,
+		password: "testpassword",
+		address:  "0x008AeEda4D805471dF9b2A5B0f38A0C3bCBA786b",
+	},
+	{
+		name:     "pbkdf2",
+		json:     `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`,
+		password: "testpassword",
+		address:  "0x008AeEda4D805471dF9b2A5B0f38A0C3bCBA786b",
+	},
+	{
+		name:     "light scrypt",
+		json:     `{"address":"45dea0fb0bba44f4fcf290bba71fd57d7117cbb8","crypto":{"cipher":"aes-128-ctr","ciphertext":"b87781948a1befd247bff51ef4063f716cf6c2d3481163e9a8f42e1f9bb74145","cipherparams":{"iv":"dc4926b48a105133d2f16b96833abf1e"},"kdf":"scrypt","kdfparams":{"dklen":32,"n":2,"p":1,"r":8,"salt":"004244bbdc51cadda545b1cfa43cff9ed2ae88e08c61f1479dbb45410722f8f0"},"mac":"39990c1684557447940d4c69e06b1b82b2aceacb43f284df65c956daf3046b85"},"id":"ce541d8d-c79b-40f8-9f8c-20f59616faba","version":3}`,
+		password: "",
+		address:  "0x45DeA0FB0bBA44f4fcF290bbA71Fd57d7117Cbb8",
+	},
+}
+
+func TestNewAccountFromKeystore(t *testing.T) {
+	dir := t.TempDir()
+	for _, vector := range keystoreVectors {
+		t.Run(vector.name, func(t *testing.T) {
+			path := filepath.Join(dir, "alice.json")
+			require.NoError(t, os.WriteFile(path, []byte(vector.json), 0o600))
+
+			account, err := NewAccountFromKeystore(path, vector.password)
+			require.NoError(t, err)
+			assert.Equal(t, "alice", account.GetName())
+			assert.Equal(t, vector.address, account.GetAddress().String())
+
+			_, err = NewAccountFromKeystore(path, vector.password+"wrong")
+			assert.ErrorIs(t, err, keystore.ErrDecrypt)
+		})
+	}
+}
+
+func TestExportKeystore(t *testing.T) {
+	fromPrivateKey, err := NewAccountFromPrivateKey("key", "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d")
+	require.NoError(t, err)
+	fromMnemonic, err := NewAccountFromMnemonic("mnemonic", "test test test test test test test test test test test junk")
+	require.NoError(t, err)
+	generated, _, err := NewAccount("generated")
+	require.NoError(t, err)
+
+	for _, account := range []*Account{fromPrivateKey, fromMnemonic, generated} {
+		keyJSON, err := account.exportKeystore("secret", keystore.LightScryptN, keystore.LightScryptP)
+		require.NoError(t, err)
+
+		imported, err := NewAccountFromKeystoreJSON(account.GetName(), keyJSON, "secret")
+		require.NoError(t, err)
+		assert.Equal(t, account.GetAddress(), imported.GetAddress())
+	}
+	assert.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", fromMnemonic.GetAddress().String())
+
+	_, err = (&Account{name: "empty", km: fromPrivateKey.km}).ExportKeystore("secret")
+	assert.ErrorIs(t, err, ErrorPrivateKeyNotAvailable)
+}
@@ -0,0 +1,196 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	ctypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	xauthsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/evmos/ethermint/crypto/ethsecp256k1"
+)
+
+// Multisig txs are signed in three steps. The unsigned tx of the multisig account is built online by the client
+// with WithGenerateOnly, every member signs it offline by SignMultisigTx and hands the PartialSignature over, and
+// CombineSignatures assembles the tx bytes once the threshold is met.
+//
+// The multisig txs can not be broadcast yet. The greenfield chain rejects every multi signature with "multi
+// signature is not allowed" (greenfield-cosmos-sdk v0.0.13, x/auth/signing/verify.go), so BroadcastRawTx and
+// SimulateRawTx return ErrMultisigNotSupported for them until the chain is upgraded to verify multi signatures.
+
+// PartialSignature is the signature of a multisig member on the unsigned tx, it can be serialized as JSON
+type PartialSignature struct {
+	// PubKey is the compressed eth_secp256k1 public key of the member
+	PubKey    []byte `json:"pub_key"`
+	Sequence  uint64 `json:"sequence"`
+	Signature []byte `json:"signature"`
+}
+
+// NewMultisigPubKey returns the threshold multisig public key of the members, the txs of it are valid if signed by
+// at least threshold members. The order of the members matters, the same members in another order give another key.
+func NewMultisigPubKey(threshold int, pubKeys []ctypes.PubKey) (multisig.PubKey, error) {
+	if threshold <= 0 || threshold > len(pubKeys) {
+		return nil, fmt.Errorf("invalid threshold %d of %d members", threshold, len(pubKeys))
+	}
+	seen := make(map[string]bool, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		if _, ok := pubKey.(*ethsecp256k1.PubKey); !ok {
+			return nil, fmt.Errorf("the member public key must be eth_secp256k1, got %T", pubKey)
+		}
+		if seen[string(pubKey.Bytes())] {
+			return nil, fmt.Errorf("duplicate member %s", pubKey.Address().String())
+		}
+		seen[string(pubKey.Bytes())] = true
+	}
+	return kmultisig.NewLegacyAminoPubKey(threshold, pubKeys), nil
+}
+
+// NewMultisigAccount returns the watch-only account of the multisig public key of the members, which builds the
+// unsigned txs of the multisig account with WithGenerateOnly
+func NewMultisigAccount(name string, threshold int, pubKeys []ctypes.PubKey) (*Account, error) {
+	pubKey, err := NewMultisigPubKey(threshold, pubKeys)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccountFromPubKey(name, pubKey)
+}
+
+// NewMultisigPlaceholder returns the multi signature data with the empty signatures of the first threshold members,
+// it is set as the signer info of the unsigned tx so that the simulation charges the gas of the signatures
+func NewMultisigPlaceholder(pubKey multisig.PubKey) *signing.MultiSignatureData {
+	pubKeys := pubKey.GetPubKeys()
+	data := multisig.NewMultisig(len(pubKeys))
+	for i := 0; i < int(pubKey.GetThreshold()); i++ {
+		multisig.AddSignature(data, &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_EIP_712}, i)
+	}
+	return data
+}
+
+// SignMultisigTx signs the unsigned tx of a multisig account with the member account without any network access
+func SignMultisigTx(unsignedTx *UnsignedTx, member *Account) (*PartialSignature, error) {
+	if unsignedTx == nil {
+		return nil, errors.New("the unsigned tx is nil")
+	}
+	if member == nil {
+		return nil, ErrorAccountIsNil
+	}
+	txConfig, txBuilder, sig, err := decodeUnsignedTx(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	multisigPubKey, ok := sig.PubKey.(multisig.PubKey)
+	if !ok {
+		return nil, errors.New("the unsigned tx is not built for a multisig account, sign it by SignTx")
+	}
+	if multisigMemberIndex(multisigPubKey, member.PubKey()) < 0 {
+		return nil, fmt.Errorf("the account %s is not a member of the multisig account", member.GetAddress().String())
+	}
+
+	// all the members sign the same bytes, which are of the multisig account
+	signBytes, err := txConfig.SignModeHandler().GetSignBytes(signing.SignMode_SIGN_MODE_EIP_712,
+		unsignedTx.signerData(), txBuilder.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	signature, err := member.Sign(signBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &PartialSignature{
+		PubKey:    member.PubKey().Bytes(),
+		Sequence:  unsignedTx.Sequence,
+		Signature: signature,
+	}, nil
+}
+
+// CombineSignatures verifies the partial signatures of the members and combines them into the multi signature of
+// the unsigned tx, it returns the signed tx bytes. The bytes can not be broadcast until the greenfield chain
+// supports multi signatures, see ErrMultisigNotSupported.
+func CombineSignatures(unsignedTx *UnsignedTx, partialSigs []*PartialSignature) ([]byte, error) {
+	if unsignedTx == nil {
+		return nil, errors.New("the unsigned tx is nil")
+	}
+	txConfig, txBuilder, sig, err := decodeUnsignedTx(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	multisigPubKey, ok := sig.PubKey.(multisig.PubKey)
+	if !ok {
+		return nil, errors.New("the unsigned tx is not built for a multisig account")
+	}
+
+	pubKeys := multisigPubKey.GetPubKeys()
+	multiSigData := multisig.NewMultisig(len(pubKeys))
+	for _, partialSig := range partialSigs {
+		if partialSig == nil {
+			continue
+		}
+		if partialSig.Sequence != unsignedTx.Sequence {
+			return nil, fmt.Errorf("the partial signature is of the sequence %d, but the unsigned tx is of %d",
+				partialSig.Sequence, unsignedTx.Sequence)
+		}
+		pubKey := &ethsecp256k1.PubKey{Key: partialSig.PubKey}
+		index := multisigMemberIndex(multisigPubKey, pubKey)
+		if index < 0 {
+			return nil, fmt.Errorf("the signer %s is not a member of the multisig account", pubKey.Address().String())
+		}
+		// the copy of the signature is verified since the verification may strip the recovery offset of it
+		sigData := &signing.SingleSignatureData{
+			SignMode:  signing.SignMode_SIGN_MODE_EIP_712,
+			Signature: append([]byte(nil), partialSig.Signature...),
+		}
+		err = xauthsigning.VerifySignature(pubKeys[index], unsignedTx.signerData(), sigData,
+			txConfig.SignModeHandler(), txBuilder.GetTx())
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature of the member %s: %w", pubKey.Address().String(), err)
+		}
+		multisig.AddSignature(multiSigData, sigData, index)
+	}
+	if len(multiSigData.Signatures) < int(multisigPubKey.GetThreshold()) {
+		return nil, fmt.Errorf("%d signatures are collected but the threshold is %d",
+			len(multiSigData.Signatures), multisigPubKey.GetThreshold())
+	}
+
+	err = txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   multisigPubKey,
+		Data:     multiSigData,
+		Sequence: unsignedTx.Sequence,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return txConfig.TxEncoder()(txBuilder.GetTx())
+}
+
+// IsMultisigTx reports whether the tx bytes are signed by a multisig account
+func IsMultisigTx(txBytes []byte) (bool, error) {
+	decoded, err := NewTxConfig().TxDecoder()(txBytes)
+	if err != nil {
+		return false, err
+	}
+	sigTx, ok := decoded.(xauthsigning.SigVerifiableTx)
+	if !ok {
+		return false, fmt.Errorf("unexpected tx type %T", decoded)
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return false, err
+	}
+	for _, sig := range sigs {
+		if _, ok = sig.Data.(*signing.MultiSignatureData); ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func multisigMemberIndex(multisigPubKey multisig.PubKey, pubKey ctypes.PubKey) int {
+	for i, member := range multisigPubKey.GetPubKeys() {
+		if member.Equals(pubKey) {
+			return i
+		}
+	}
+	return -1
+}
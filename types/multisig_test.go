@@ -0,0 +1,138 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	gnfdTypes "github.com/bnb-chain/greenfield/types"
+	permTypes "github.com/bnb-chain/greenfield/x/permission/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	ctypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	xauthsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMultisigMembers(t *testing.T) []*Account {
+	members := make([]*Account, 0, 3)
+	for i := uint32(0); i < 3; i++ {
+		member, err := NewAccountFromMnemonicWithIndex("member", testMnemonic, 0, i)
+		require.NoError(t, err)
+		members = append(members, member)
+	}
+	return members
+}
+
+// newTestMultisigUnsignedTx builds the unsigned tx of the multisig account in the same way as the client does
+func newTestMultisigUnsignedTx(t *testing.T, account *Account, msg sdk.Msg) *UnsignedTx {
+	txConfig := NewTxConfig()
+	txBuilder := txConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(msg))
+	require.NoError(t, txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   account.PubKey(),
+		Data:     NewMultisigPlaceholder(account.PubKey().(multisig.PubKey)),
+		Sequence: 2,
+	}))
+	txBuilder.SetGasLimit(1200)
+
+	txJSON, err := txConfig.TxJSONEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+	return &UnsignedTx{ChainID: "greenfield_9000-121", AccountNumber: 5, Sequence: 2, Tx: txJSON}
+}
+
+func TestNewMultisigPubKey(t *testing.T) {
+	members := newTestMultisigMembers(t)
+	pubKeys := []ctypes.PubKey{members[0].PubKey(), members[1].PubKey(), members[2].PubKey()}
+
+	account, err := NewMultisigAccount("treasury", 2, pubKeys)
+	require.NoError(t, err)
+	assert.Equal(t, sdk.AccAddress(account.PubKey().Address()), account.GetAddress())
+
+	_, err = NewMultisigPubKey(0, pubKeys)
+	assert.Error(t, err)
+	_, err = NewMultisigPubKey(4, pubKeys)
+	assert.Error(t, err)
+	_, err = NewMultisigPubKey(2, []ctypes.PubKey{pubKeys[0], pubKeys[0]})
+	assert.Error(t, err)
+}
+
+func TestMultisigSignAndCombine(t *testing.T) {
+	members := newTestMultisigMembers(t)
+	account, err := NewMultisigAccount("treasury", 2,
+		[]ctypes.PubKey{members[0].PubKey(), members[1].PubKey(), members[2].PubKey()})
+	require.NoError(t, err)
+
+	principal := permTypes.NewPrincipalWithAccount(members[0].GetAddress())
+	msgs := []sdk.Msg{
+		storageTypes.NewMsgCreateBucket(account.GetAddress(), "treasury-bucket", storageTypes.VISIBILITY_TYPE_PRIVATE,
+			members[0].GetAddress(), nil, 0, nil, 0),
+		storageTypes.NewMsgPutPolicy(account.GetAddress(), gnfdTypes.NewBucketGRN("treasury-bucket").String(), principal,
+			[]*permTypes.Statement{{Effect: permTypes.EFFECT_ALLOW, Actions: []permTypes.ActionType{permTypes.ACTION_GET_OBJECT}}}, nil),
+	}
+	for _, msg := range msgs {
+		unsignedTx := newTestMultisigUnsignedTx(t, account, msg)
+
+		_, err = SignTx(unsignedTx, members[0])
+		assert.Error(t, err)
+
+		// the partial signatures of the members survive the JSON round trip
+		partialSigs := make([]*PartialSignature, 0, 2)
+		for _, member := range []*Account{members[2], members[0]} {
+			partialSig, err := SignMultisigTx(unsignedTx, member)
+			require.NoError(t, err)
+			data, err := json.Marshal(partialSig)
+			require.NoError(t, err)
+			decoded := &PartialSignature{}
+			require.NoError(t, json.Unmarshal(data, decoded))
+			partialSigs = append(partialSigs, decoded)
+		}
+
+		_, err = CombineSignatures(unsignedTx, partialSigs[:1])
+		assert.Error(t, err)
+		txBytes, err := CombineSignatures(unsignedTx, partialSigs)
+		require.NoError(t, err)
+
+		txConfig := NewTxConfig()
+		signedTx, err := txConfig.TxDecoder()(txBytes)
+		require.NoError(t, err)
+		sigs, err := signedTx.(xauthsigning.SigVerifiableTx).GetSignaturesV2()
+		require.NoError(t, err)
+		require.Len(t, sigs, 1)
+		assert.True(t, account.PubKey().Equals(sigs[0].PubKey))
+		multiSigData, ok := sigs[0].Data.(*signing.MultiSignatureData)
+		require.True(t, ok)
+		require.Len(t, multiSigData.Signatures, 2)
+		isMultisig, err := IsMultisigTx(txBytes)
+		require.NoError(t, err)
+		assert.True(t, isMultisig)
+
+		// the signatures are ordered by the members and verified against the sign bytes of the multisig account
+		signers := []*Account{members[0], members[2]}
+		for i, sigData := range multiSigData.Signatures {
+			err = xauthsigning.VerifySignature(signers[i].PubKey(), unsignedTx.signerData(), sigData,
+				txConfig.SignModeHandler(), signedTx)
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestMultisigRejectsOutsider(t *testing.T) {
+	members := newTestMultisigMembers(t)
+	account, err := NewMultisigAccount("treasury", 1, []ctypes.PubKey{members[0].PubKey(), members[1].PubKey()})
+	require.NoError(t, err)
+	msg := storageTypes.NewMsgDeleteBucket(account.GetAddress(), "treasury-bucket")
+	unsignedTx := newTestMultisigUnsignedTx(t, account, msg)
+
+	_, err = SignMultisigTx(unsignedTx, members[2])
+	assert.Error(t, err)
+
+	// the signature of a member can't be passed off as the one of another member
+	forgedSig, err := SignMultisigTx(unsignedTx, members[0])
+	require.NoError(t, err)
+	forgedSig.PubKey = members[1].PubKey().Bytes()
+	_, err = CombineSignatures(unsignedTx, []*PartialSignature{forgedSig})
+	assert.Error(t, err)
+}
@@ -12,11 +12,13 @@ import (
 
 // CreateBucketOptions indicates the meta to construct createBucket msg of storage module
 // PaymentAddress  indicates the HEX-encoded string of the payment address
+// SPSelectOption  indicates how the primary SP is selected if it is AutoPrimarySP
 type CreateBucketOptions struct {
 	Visibility     storageTypes.VisibilityType
 	TxOpts         *gnfdsdktypes.TxOption
 	PaymentAddress string
 	ChargedQuota   uint64
+	SPSelectOption SPSelectOption
 }
 
 type VoteProposalOptions struct {
@@ -43,6 +45,23 @@ type GrantDepositForStorageProviderOptions struct {
 	TxOption   gnfdsdktypes.TxOption
 }
 
+// TxBatchOption indicates the limits of the txs a TxBatch is split into, a limit of zero is the default one
+type TxBatchOption struct {
+	// MaxGasPerTx is the max simulated gas of a tx, the gas is unlimited by default
+	MaxGasPerTx uint64
+	// MaxBytesPerTx is the max size of the msgs of a tx, DefaultBatchMaxBytesPerTx by default
+	MaxBytesPerTx int
+	// MaxMsgsPerTx is the max number of the msgs of a tx, the number is unlimited by default
+	MaxMsgsPerTx int
+	TxOption     gnfdsdktypes.TxOption
+}
+
+// GrantAuthorizationOptions indicates the options of the grant, the grant never expires if Expiration is nil
+type GrantAuthorizationOptions struct {
+	Expiration *time.Time
+	TxOption   gnfdsdktypes.TxOption
+}
+
 type DeleteBucketOption struct {
 	TxOpts *gnfdsdktypes.TxOption
 }
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	DefaultRetryMaxAttempts    = 3
+	DefaultRetryInitialBackoff = 500 * time.Millisecond
+	DefaultRetryMaxBackoff     = 10 * time.Second
+)
+
+// RetryPolicy indicates how the failed requests to SP are retried. Only the idempotent requests are retried, and a
+// request with a body is retried only if the body can be rewound, i.e. it is an io.Seeker.
+type RetryPolicy struct {
+	// MaxAttempts is the max number of the attempts of a request including the first one, a request is sent once if
+	// it is less than 2
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, the wait doubles for each following retry up to MaxBackoff.
+	// A random jitter of up to half the wait is subtracted, so that the clients failing together don't retry together.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RetryableStatusCodes are the status codes of the SP responses which are retried
+	RetryableStatusCodes []int
+	// RetryableErrors are the errors of the requests which are retried, an error matches if errors.Is reports so.
+	// The network timeouts are always retried.
+	RetryableErrors []error
+}
+
+// DefaultRetryPolicy returns the retry policy used by the client if no other one is set, which retries the
+// throttled requests, the server errors of SP and the broken connections
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    DefaultRetryMaxAttempts,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableErrors: []error{io.EOF, io.ErrUnexpectedEOF, syscall.ECONNRESET, syscall.ECONNREFUSED},
+	}
+}
+
+// IsRetryable reports whether the request failing with err is retried
+func (p *RetryPolicy) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var errResp ErrResponse
+	if errors.As(err, &errResp) {
+		for _, code := range p.RetryableStatusCodes {
+			if code == errResp.StatusCode {
+				return true
+			}
+		}
+		return false
+	}
+	for _, retryableErr := range p.RetryableErrors {
+		if errors.Is(err, retryableErr) {
+			return true
+		}
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// Backoff returns the wait before the retry, which is the attempt-th one starting from 1
+func (p *RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || backoff < p.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return backoff - time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
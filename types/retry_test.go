@@ -0,0 +1,47 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRetryPolicyIsRetryable(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	assert.False(t, policy.IsRetryable(nil))
+	assert.True(t, policy.IsRetryable(ErrResponse{StatusCode: http.StatusServiceUnavailable}))
+	assert.False(t, policy.IsRetryable(ErrResponse{StatusCode: http.StatusForbidden}))
+	assert.True(t, policy.IsRetryable(&url.Error{Op: "Get", URL: "http://sp", Err: io.EOF}))
+	assert.True(t, policy.IsRetryable(fmt.Errorf("dial: %w", syscall.ECONNREFUSED)))
+	assert.True(t, policy.IsRetryable(&url.Error{Op: "Get", URL: "http://sp", Err: timeoutError{}}))
+	assert.False(t, policy.IsRetryable(errors.New("invalid request")))
+
+	policy.RetryableStatusCodes = []int{http.StatusForbidden}
+	assert.True(t, policy.IsRetryable(ErrResponse{StatusCode: http.StatusForbidden}))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 3 * time.Second}
+	for i := 0; i < 100; i++ {
+		backoff := policy.Backoff(1)
+		assert.True(t, backoff >= time.Second/2 && backoff <= time.Second, backoff)
+		backoff = policy.Backoff(2)
+		assert.True(t, backoff >= time.Second && backoff <= 2*time.Second, backoff)
+		backoff = policy.Backoff(10)
+		assert.True(t, backoff >= 3*time.Second/2 && backoff <= 3*time.Second, backoff)
+	}
+	assert.Zero(t, (&RetryPolicy{}).Backoff(3))
+}
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/bnb-chain/greenfield/sdk/keys"
+	ctypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Signer signs the SP requests and the chain transactions of an account whose private key may be
+// kept outside of the process, such as in a remote signing service or a hardware wallet.
+type Signer interface {
+	// GetAddress returns the address of the account
+	GetAddress() sdk.AccAddress
+	// PubKey returns the eth_secp256k1 public key of the account
+	PubKey() ctypes.PubKey
+	// Sign returns the 65 bytes recoverable eth_secp256k1 signature of the msg. The msg of 32 bytes is
+	// signed as the digest, otherwise its keccak256 hash is signed, the same as the in-process key.
+	Sign(msg []byte) ([]byte, error)
+}
+
+var _ Signer = (*Account)(nil)
+
+// NewAccountFromSigner returns the account which signs everything with the signer, both the SP requests and
+// the transactions sent by the client with the account are signed by it. The account can't be exported.
+func NewAccountFromSigner(name string, signer Signer) (*Account, error) {
+	if signer == nil {
+		return nil, ErrorSignerIsNil
+	}
+	return &Account{
+		name: name,
+		km:   &signerKeyManager{signer: signer},
+	}, nil
+}
+
+// NewAccountFromPubKey returns the watch-only account of the public key, it can build the unsigned txs of the
+// account for offline signing but fails to sign anything
+func NewAccountFromPubKey(name string, pubKey ctypes.PubKey) (*Account, error) {
+	if pubKey == nil {
+		return nil, errors.New("the public key is nil")
+	}
+	return NewAccountFromSigner(name, watchOnlySigner{pubKey: pubKey})
+}
+
+// watchOnlySigner is the Signer of a public key without the private key
+type watchOnlySigner struct {
+	pubKey ctypes.PubKey
+}
+
+func (s watchOnlySigner) GetAddress() sdk.AccAddress {
+	return sdk.AccAddress(s.pubKey.Address())
+}
+
+func (s watchOnlySigner) PubKey() ctypes.PubKey {
+	return s.pubKey
+}
+
+func (s watchOnlySigner) Sign(_ []byte) ([]byte, error) {
+	return nil, ErrorWatchOnlyAccount
+}
+
+// signerKeyManager adapts the Signer to the KeyManager which is used by the chain client to sign the txs
+type signerKeyManager struct {
+	signer Signer
+}
+
+var _ keys.KeyManager = (*signerKeyManager)(nil)
+
+// Bytes returns nil since the private key is kept by the signer
+func (km *signerKeyManager) Bytes() []byte {
+	return nil
+}
+
+func (km *signerKeyManager) Sign(msg []byte) ([]byte, error) {
+	return km.signer.Sign(msg)
+}
+
+func (km *signerKeyManager) PubKey() ctypes.PubKey {
+	return km.signer.PubKey()
+}
+
+func (km *signerKeyManager) Equals(other ctypes.LedgerPrivKey) bool {
+	return km.Type() == other.Type() && bytes.Equal(km.PubKey().Bytes(), other.PubKey().Bytes())
+}
+
+func (km *signerKeyManager) Type() string {
+	return km.signer.PubKey().Type()
+}
+
+func (km *signerKeyManager) GetAddr() sdk.AccAddress {
+	return km.signer.GetAddress()
+}
+
+func (km *signerKeyManager) String() string { return km.signer.GetAddress().String() }
+func (km *signerKeyManager) ProtoMessage()  {}
+func (km *signerKeyManager) Reset()         {}
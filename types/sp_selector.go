@@ -0,0 +1,167 @@
+package types
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	spTypes "github.com/bnb-chain/greenfield/x/sp/types"
+)
+
+// AutoPrimarySP is the primary SP address with which CreateBucket selects the primary SP by SelectStorageProviders
+const AutoPrimarySP = "auto"
+
+const (
+	// DefaultSPProbeTimeout is the time an SP endpoint is given to respond to the health probe
+	DefaultSPProbeTimeout = 3 * time.Second
+	// spRegionTagPrefix prefixes the region tags in the details of the SP description, such as "region:us-east"
+	spRegionTagPrefix = "region:"
+)
+
+// SPScoreWeights indicates how much each metric of the SPs counts in their scores. Each metric is scaled to a value
+// between 0 and 1 among the candidates, so a weight is the most score the metric can add.
+type SPScoreWeights struct {
+	// Latency scores the SPs responding faster higher
+	Latency float64
+	// StorePrice and ReadPrice score the cheaper SPs higher
+	StorePrice float64
+	ReadPrice  float64
+	// FreeReadQuota scores the SPs offering more free read quota higher
+	FreeReadQuota float64
+	// Region scores the SPs in the preferred regions higher
+	Region float64
+}
+
+// DefaultSPScoreWeights returns the weights used if SPSelectOption sets none
+func DefaultSPScoreWeights() SPScoreWeights {
+	return SPScoreWeights{Latency: 1, StorePrice: 1, ReadPrice: 0.5, FreeReadQuota: 0.5, Region: 2}
+}
+
+// NeedPrice reports whether the storage prices of the SPs must be queried to score them
+func (w SPScoreWeights) NeedPrice() bool {
+	return w.StorePrice != 0 || w.ReadPrice != 0 || w.FreeReadQuota != 0
+}
+
+// SPSelectOption indicates how the SPs are selected
+type SPSelectOption struct {
+	// Regions are the preferred region tags, see SPRegions
+	Regions []string
+	// Weights are the weights of the metrics scoring the SPs, DefaultSPScoreWeights is used if it is nil
+	Weights *SPScoreWeights
+	// ProbeTimeout is the time an SP is given to respond to the health probe, DefaultSPProbeTimeout by default
+	ProbeTimeout time.Duration
+}
+
+// SPCandidate is an in-service SP with the metrics it is scored by
+type SPCandidate struct {
+	Info     spTypes.StorageProvider
+	Endpoint *url.URL
+	// Healthy indicates whether the SP responded to the health probe
+	Healthy bool
+	Latency time.Duration
+	// Price is the storage price of the SP, it is nil if the prices are not weighed or fail to be queried
+	Price   *spTypes.SpStoragePrice
+	Regions []string
+	Score   float64
+}
+
+// SPRegions returns the region tags of the SP, which are the words prefixed by "region:" in the details of its
+// description, e.g. "region:us-east region:us"
+func SPRegions(description spTypes.Description) []string {
+	regions := make([]string, 0)
+	for _, word := range strings.FieldsFunc(description.Details, func(r rune) bool {
+		return r == ' ' || r == ',' || r == ';' || r == '\n' || r == '\t'
+	}) {
+		if strings.HasPrefix(strings.ToLower(word), spRegionTagPrefix) && len(word) > len(spRegionTagPrefix) {
+			regions = append(regions, strings.ToLower(word[len(spRegionTagPrefix):]))
+		}
+	}
+	return regions
+}
+
+// ScoreSPCandidates scores the healthy candidates by the weights and returns them from the highest score
+func ScoreSPCandidates(candidates []*SPCandidate, regions []string, weights SPScoreWeights) []*SPCandidate {
+	healthy := make([]*SPCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.Healthy {
+			candidate.Score = 0
+			healthy = append(healthy, candidate)
+		}
+	}
+
+	// lower is better for the latency and the prices, higher is better for the free read quota
+	addScore(healthy, weights.Latency, false, func(c *SPCandidate) (float64, bool) {
+		return float64(c.Latency), true
+	})
+	addScore(healthy, weights.StorePrice, false, func(c *SPCandidate) (float64, bool) {
+		if c.Price == nil || c.Price.StorePrice.IsNil() {
+			return 0, false
+		}
+		return c.Price.StorePrice.MustFloat64(), true
+	})
+	addScore(healthy, weights.ReadPrice, false, func(c *SPCandidate) (float64, bool) {
+		if c.Price == nil || c.Price.ReadPrice.IsNil() {
+			return 0, false
+		}
+		return c.Price.ReadPrice.MustFloat64(), true
+	})
+	addScore(healthy, weights.FreeReadQuota, true, func(c *SPCandidate) (float64, bool) {
+		if c.Price == nil {
+			return 0, false
+		}
+		return float64(c.Price.FreeReadQuota), true
+	})
+	if len(regions) > 0 && weights.Region != 0 {
+		preferred := make(map[string]bool, len(regions))
+		for _, region := range regions {
+			preferred[strings.ToLower(region)] = true
+		}
+		for _, candidate := range healthy {
+			for _, region := range candidate.Regions {
+				if preferred[region] {
+					candidate.Score += weights.Region
+					break
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(healthy, func(i, j int) bool {
+		return healthy[i].Score > healthy[j].Score
+	})
+	return healthy
+}
+
+// addScore adds the metric scaled between 0 and 1 among the candidates times the weight to their scores, the
+// candidates without the metric get nothing
+func addScore(candidates []*SPCandidate, weight float64, higherIsBetter bool, metric func(c *SPCandidate) (float64, bool)) {
+	if weight == 0 {
+		return
+	}
+	values := make(map[*SPCandidate]float64, len(candidates))
+	var min, max float64
+	for _, candidate := range candidates {
+		value, ok := metric(candidate)
+		if !ok {
+			continue
+		}
+		if len(values) == 0 || value < min {
+			min = value
+		}
+		if len(values) == 0 || value > max {
+			max = value
+		}
+		values[candidate] = value
+	}
+	for candidate, value := range values {
+		scaled := 1.0
+		if max > min {
+			scaled = (max - value) / (max - min)
+			if higherIsBetter {
+				scaled = 1 - scaled
+			}
+		}
+		candidate.Score += weight * scaled
+	}
+}
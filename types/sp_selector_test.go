@@ -0,0 +1,46 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	spTypes "github.com/bnb-chain/greenfield/x/sp/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPRegions(t *testing.T) {
+	assert.Equal(t, []string{"us-east", "us"}, SPRegions(spTypes.Description{Details: "fast SP, Region:US-East;region:us region:"}))
+	assert.Empty(t, SPRegions(spTypes.Description{Details: "no region"}))
+}
+
+func TestScoreSPCandidates(t *testing.T) {
+	price := func(storePrice int64, freeReadQuota uint64) *spTypes.SpStoragePrice {
+		return &spTypes.SpStoragePrice{StorePrice: sdk.NewDec(storePrice), ReadPrice: sdk.NewDec(1), FreeReadQuota: freeReadQuota}
+	}
+	fast := &SPCandidate{Healthy: true, Latency: 10 * time.Millisecond, Price: price(10, 0), Regions: []string{"eu"}}
+	cheap := &SPCandidate{Healthy: true, Latency: 100 * time.Millisecond, Price: price(1, 100), Regions: []string{"us"}}
+	down := &SPCandidate{Healthy: false, Price: price(0, 1000)}
+
+	selected := ScoreSPCandidates([]*SPCandidate{fast, cheap, down}, nil, SPScoreWeights{Latency: 1})
+	require.Equal(t, []*SPCandidate{fast, cheap}, selected)
+	assert.Equal(t, 1.0, fast.Score)
+	assert.Equal(t, 0.0, cheap.Score)
+
+	selected = ScoreSPCandidates([]*SPCandidate{fast, cheap, down}, nil, DefaultSPScoreWeights())
+	require.Equal(t, []*SPCandidate{cheap, fast}, selected)
+	// the equal read prices score the same
+	assert.Equal(t, 1.0+0.5+0.5, cheap.Score)
+	assert.Equal(t, 1.0+0.5, fast.Score)
+
+	selected = ScoreSPCandidates([]*SPCandidate{fast, cheap}, []string{"EU"}, SPScoreWeights{Latency: 1, StorePrice: 1, Region: 2})
+	require.Equal(t, []*SPCandidate{fast, cheap}, selected)
+	assert.Equal(t, 3.0, fast.Score)
+
+	// the candidate without the price gets nothing for it
+	cheap.Price = nil
+	selected = ScoreSPCandidates([]*SPCandidate{fast, cheap}, nil, SPScoreWeights{StorePrice: 1})
+	require.Equal(t, []*SPCandidate{fast, cheap}, selected)
+	assert.Equal(t, 0.0, cheap.Score)
+}
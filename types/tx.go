@@ -0,0 +1,110 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	clitx "github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	xauthsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// UnsignedTx is the transaction built by the online side with the account number, sequence, gas and fee
+// filled in. It can be serialized as JSON, carried to an air-gapped machine and signed there by SignTx.
+type UnsignedTx struct {
+	ChainID       string `json:"chain_id"`
+	AccountNumber uint64 `json:"account_number"`
+	Sequence      uint64 `json:"sequence"`
+	// Tx is the JSON encoded tx with the signer info but without the signature
+	Tx json.RawMessage `json:"tx"`
+}
+
+// DryRunResult is what a tx would do, it is filled by the write APIs called with a dry-run context
+type DryRunResult struct {
+	// Msgs are the msgs of the tx, which are built and validated as the API does for sending them
+	Msgs []sdk.Msg
+	// GasEstimate is the gas and the fee the tx would take
+	GasEstimate *GasEstimate
+	// Events are the events emitted by the simulation of the tx
+	Events []abci.Event
+}
+
+// NewTxConfig returns the tx config of greenfield, which signs the txs in EIP712 sign mode
+func NewTxConfig() sdkclient.TxConfig {
+	return authtx.NewTxConfig(gnfdSdkTypes.Codec(), []signing.SignMode{signing.SignMode_SIGN_MODE_EIP_712})
+}
+
+// SignTx signs the unsigned tx with the account without any network access, and returns the tx bytes
+// which can be broadcast by BroadcastRawTx.
+func SignTx(unsignedTx *UnsignedTx, account *Account) ([]byte, error) {
+	if unsignedTx == nil {
+		return nil, errors.New("the unsigned tx is nil")
+	}
+	if account == nil {
+		return nil, ErrorAccountIsNil
+	}
+
+	txConfig, txBuilder, sig, err := decodeUnsignedTx(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := sig.PubKey.(multisig.PubKey); ok {
+		return nil, errors.New("the unsigned tx is built for a multisig account, sign it by SignMultisigTx")
+	}
+	if !bytes.Equal(sig.PubKey.Bytes(), account.PubKey().Bytes()) {
+		return nil, fmt.Errorf("the unsigned tx is not built for the account %s", account.GetAddress().String())
+	}
+
+	sig, err = clitx.SignWithPrivKey(signing.SignMode_SIGN_MODE_EIP_712, unsignedTx.signerData(), txBuilder,
+		account.GetKeyManager(), txConfig, unsignedTx.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	if err = txBuilder.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+	return txConfig.TxEncoder()(txBuilder.GetTx())
+}
+
+// decodeUnsignedTx decodes the tx of the unsigned tx and returns its only signer info
+func decodeUnsignedTx(unsignedTx *UnsignedTx) (sdkclient.TxConfig, sdkclient.TxBuilder, signing.SignatureV2, error) {
+	txConfig := NewTxConfig()
+	decodedTx, err := txConfig.TxJSONDecoder()(unsignedTx.Tx)
+	if err != nil {
+		return nil, nil, signing.SignatureV2{}, fmt.Errorf("decode the unsigned tx failed: %w", err)
+	}
+	txBuilder, err := txConfig.WrapTxBuilder(decodedTx)
+	if err != nil {
+		return nil, nil, signing.SignatureV2{}, err
+	}
+
+	// the online side sets the signer info of the account
+	sigs, err := txBuilder.GetTx().GetSignaturesV2()
+	if err != nil {
+		return nil, nil, signing.SignatureV2{}, err
+	}
+	if len(sigs) != 1 || sigs[0].PubKey == nil {
+		return nil, nil, signing.SignatureV2{}, errors.New("the unsigned tx must contain the signer info of exactly one signer")
+	}
+	if sigs[0].Sequence != unsignedTx.Sequence {
+		return nil, nil, signing.SignatureV2{}, fmt.Errorf("the sequence %d of the signer info does not match the sequence %d of the unsigned tx",
+			sigs[0].Sequence, unsignedTx.Sequence)
+	}
+	return txConfig, txBuilder, sigs[0], nil
+}
+
+func (t *UnsignedTx) signerData() xauthsigning.SignerData {
+	return xauthsigning.SignerData{
+		ChainID:       t.ChainID,
+		AccountNumber: t.AccountNumber,
+		Sequence:      t.Sequence,
+	}
+}
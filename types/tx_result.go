@@ -0,0 +1,139 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/proto"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// AuthzMsgIndexKey is the event attribute the authz module tags the events of the msgs of a MsgExec with
+const AuthzMsgIndexKey = "authz_msg_index"
+
+// TxResult is the result of a tx included in a block
+type TxResult struct {
+	TxHash    string
+	Height    int64
+	Code      uint32
+	RawLog    string
+	GasWanted int64
+	GasUsed   int64
+	// Fee is the fee paid for the tx
+	Fee  sdk.Coins
+	Memo string
+	// Msgs are the msgs of the tx
+	Msgs []sdk.Msg
+	// Events are the typed events emitted by the tx, such as *storageTypes.EventCreateBucket carrying the bucket id
+	Events []proto.Message
+	// RawEvents are all the events emitted by the tx, including the ones which are not typed
+	RawEvents []abci.Event
+}
+
+// NewTxResult returns the result of the tx queried from chain
+func NewTxResult(txResp *sdk.TxResponse) (*TxResult, error) {
+	if txResp == nil {
+		return nil, errors.New("the tx response is nil")
+	}
+	result := &TxResult{
+		TxHash:    txResp.TxHash,
+		Height:    txResp.Height,
+		Code:      txResp.Code,
+		RawLog:    txResp.RawLog,
+		GasWanted: txResp.GasWanted,
+		GasUsed:   txResp.GasUsed,
+		Events:    ParseTypedEvents(txResp.Events),
+		RawEvents: txResp.Events,
+	}
+	if txResp.Tx != nil {
+		decodedTx, err := DecodeTx(txResp.Tx.Value)
+		if err != nil {
+			return nil, err
+		}
+		result.Fee = decodedTx.Fee
+		result.Memo = decodedTx.Memo
+		result.Msgs = decodedTx.Msgs
+	}
+	return result, nil
+}
+
+// DefaultTxQueryLimit is the default number of the txs of a page searched by TxQuery
+const DefaultTxQueryLimit = 30
+
+// TxQuery indicates the conditions of the txs to search, the txs must meet all the conditions. The node must
+// index the event attributes the conditions are on, which it does by default.
+type TxQuery struct {
+	// Sender is the HEX-encoded address of the signer of the msgs
+	Sender string
+	// MsgType is the type URL of the msgs, such as sdk.MsgTypeURL(&storageTypes.MsgDeleteObject{})
+	MsgType string
+	// MinHeight and MaxHeight bound the heights of the txs, a bound of zero is open
+	MinHeight int64
+	MaxHeight int64
+	// Conditions are the extra conditions on the events of the txs in the query syntax of tendermint,
+	// such as "bnbchain.greenfield.storage.EventDeleteObject.bucket_name='\"my-bucket\"'"
+	Conditions []string
+	// Page is the page of the txs starting from 1, the first page by default
+	Page int
+	// Limit is the number of the txs of a page, DefaultTxQueryLimit by default
+	Limit int
+	// Descending orders the txs from the newest one
+	Descending bool
+}
+
+// Query returns the tendermint query of the conditions
+func (q TxQuery) Query() (string, error) {
+	conditions := make([]string, 0)
+	if q.Sender != "" {
+		sender, err := sdk.AccAddressFromHexUnsafe(q.Sender)
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeySender, sender.String()))
+	}
+	if q.MsgType != "" {
+		conditions = append(conditions, fmt.Sprintf("%s.%s='%s'", sdk.EventTypeMessage, sdk.AttributeKeyAction, q.MsgType))
+	}
+	if q.MinHeight > 0 {
+		conditions = append(conditions, fmt.Sprintf("tx.height>=%d", q.MinHeight))
+	}
+	if q.MaxHeight > 0 {
+		conditions = append(conditions, fmt.Sprintf("tx.height<=%d", q.MaxHeight))
+	}
+	conditions = append(conditions, q.Conditions...)
+	if len(conditions) == 0 {
+		// the query of tendermint can't be empty
+		conditions = append(conditions, "tx.height>0")
+	}
+	return strings.Join(conditions, " AND "), nil
+}
+
+// ParseTypedEvents returns the typed events among the events, the others are skipped
+func ParseTypedEvents(events []abci.Event) []proto.Message {
+	typedEvents := make([]proto.Message, 0)
+	for _, event := range events {
+		if proto.MessageType(event.Type) == nil {
+			continue
+		}
+		typedEvent, err := ParseTypedEvent(event)
+		if err != nil {
+			continue
+		}
+		typedEvents = append(typedEvents, typedEvent)
+	}
+	return typedEvents
+}
+
+// ParseTypedEvent decodes the typed event, the event can be emitted by a msg executed in a MsgExec
+func ParseTypedEvent(event abci.Event) (proto.Message, error) {
+	// the attribute tagged by the authz module is not a field of the typed event
+	attrs := make([]abci.EventAttribute, 0, len(event.Attributes))
+	for _, attr := range event.Attributes {
+		if string(attr.Key) != AuthzMsgIndexKey {
+			attrs = append(attrs, attr)
+		}
+	}
+	return sdk.ParseTypedEvent(abci.Event{Type: event.Type, Attributes: attrs})
+}
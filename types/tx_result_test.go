@@ -0,0 +1,85 @@
+package types
+
+import (
+	"testing"
+
+	gnfdsdktypes "github.com/bnb-chain/greenfield/sdk/types"
+	storageTypes "github.com/bnb-chain/greenfield/x/storage/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestNewTxResult(t *testing.T) {
+	fee := sdk.NewCoins(sdk.NewInt64Coin(gnfdsdktypes.Denom, 1200))
+	creator, _, err := NewAccount("creator")
+	require.NoError(t, err)
+	msgAny, err := codectypes.NewAnyWithValue(&storageTypes.MsgCreateBucket{Creator: creator.GetAddress().String(), BucketName: "bucket"})
+	require.NoError(t, err)
+	txAny, err := codectypes.NewAnyWithValue(&tx.Tx{
+		Body:     &tx.TxBody{Messages: []*codectypes.Any{msgAny}, Memo: "memo"},
+		AuthInfo: &tx.AuthInfo{Fee: &tx.Fee{Amount: fee, GasLimit: 1200}},
+	})
+	require.NoError(t, err)
+
+	createBucket, err := sdk.TypedEventToEvent(&storageTypes.EventCreateBucket{BucketName: "bucket", BucketId: sdk.NewUint(7)})
+	require.NoError(t, err)
+	createGroup, err := sdk.TypedEventToEvent(&storageTypes.EventCreateGroup{GroupName: "group", GroupId: sdk.NewUint(9)})
+	require.NoError(t, err)
+	// the event of a msg executed by a MsgExec
+	createGroup.Attributes = append(createGroup.Attributes, abci.EventAttribute{Key: []byte(AuthzMsgIndexKey), Value: []byte("0")})
+	events := []abci.Event{
+		{Type: "message", Attributes: []abci.EventAttribute{{Key: []byte("action"), Value: []byte("/greenfield.storage.MsgCreateBucket")}}},
+		abci.Event(createBucket),
+		abci.Event(createGroup),
+	}
+
+	result, err := NewTxResult(&sdk.TxResponse{TxHash: "HASH", Height: 10, GasWanted: 1200, GasUsed: 1000, Tx: txAny, Events: events})
+	require.NoError(t, err)
+	assert.Equal(t, "HASH", result.TxHash)
+	assert.Equal(t, int64(10), result.Height)
+	assert.Equal(t, int64(1000), result.GasUsed)
+	assert.Equal(t, fee, result.Fee)
+	assert.Equal(t, "memo", result.Memo)
+	require.Len(t, result.Msgs, 1)
+	msg, ok := result.Msgs[0].(*storageTypes.MsgCreateBucket)
+	require.True(t, ok)
+	assert.Equal(t, "bucket", msg.BucketName)
+	assert.Len(t, result.RawEvents, 3)
+	require.Len(t, result.Events, 2)
+	bucketEvent, ok := result.Events[0].(*storageTypes.EventCreateBucket)
+	require.True(t, ok)
+	assert.Equal(t, sdk.NewUint(7), bucketEvent.BucketId)
+	groupEvent, ok := result.Events[1].(*storageTypes.EventCreateGroup)
+	require.True(t, ok)
+	assert.Equal(t, sdk.NewUint(9), groupEvent.GroupId)
+
+	_, err = NewTxResult(nil)
+	assert.Error(t, err)
+}
+
+func TestTxQuery(t *testing.T) {
+	q, err := TxQuery{}.Query()
+	require.NoError(t, err)
+	assert.Equal(t, "tx.height>0", q)
+
+	sender, _, err := NewAccount("alice")
+	require.NoError(t, err)
+	q, err = TxQuery{
+		Sender:     sender.GetAddress().String(),
+		MsgType:    sdk.MsgTypeURL(&storageTypes.MsgCreateBucket{}),
+		MinHeight:  10,
+		MaxHeight:  20,
+		Conditions: []string{"bnbchain.greenfield.storage.EventCreateBucket.bucket_name='\"bucket\"'"},
+	}.Query()
+	require.NoError(t, err)
+	assert.Equal(t, "message.sender='"+sender.GetAddress().String()+"' AND "+
+		"message.action='/bnbchain.greenfield.storage.MsgCreateBucket' AND tx.height>=10 AND tx.height<=20 AND "+
+		"bnbchain.greenfield.storage.EventCreateBucket.bucket_name='\"bucket\"'", q)
+
+	_, err = TxQuery{Sender: "invalid"}.Query()
+	assert.Error(t, err)
+}
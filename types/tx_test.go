@@ -0,0 +1,93 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"cosmossdk.io/math"
+	gnfdSdkTypes "github.com/bnb-chain/greenfield/sdk/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	xauthsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestUnsignedTx builds the unsigned tx in the same way as the client does on the online side
+func newTestUnsignedTx(t *testing.T, account *Account) *UnsignedTx {
+	txConfig := NewTxConfig()
+	txBuilder := txConfig.NewTxBuilder()
+	msg := bankTypes.NewMsgSend(account.GetAddress(), account.GetAddress(),
+		sdk.NewCoins(sdk.NewCoin(gnfdSdkTypes.Denom, math.NewInt(100))))
+	require.NoError(t, txBuilder.SetMsgs(msg))
+	require.NoError(t, txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   account.PubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_EIP_712},
+		Sequence: 7,
+	}))
+	txBuilder.SetGasLimit(1200)
+	txBuilder.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(gnfdSdkTypes.Denom, math.NewInt(6000000000000))))
+
+	txJSON, err := txConfig.TxJSONEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+	return &UnsignedTx{ChainID: "greenfield_9000-121", AccountNumber: 3, Sequence: 7, Tx: txJSON}
+}
+
+func TestSignTx(t *testing.T) {
+	account, err := NewAccountFromMnemonic("cold", testMnemonic)
+	require.NoError(t, err)
+	watchOnly, err := NewAccountFromPubKey("watch-only", account.PubKey())
+	require.NoError(t, err)
+	assert.Equal(t, account.GetAddress(), watchOnly.GetAddress())
+
+	// the unsigned tx survives the JSON round trip to the offline side
+	data, err := json.Marshal(newTestUnsignedTx(t, watchOnly))
+	require.NoError(t, err)
+	unsignedTx := &UnsignedTx{}
+	require.NoError(t, json.Unmarshal(data, unsignedTx))
+
+	txBytes, err := SignTx(unsignedTx, account)
+	require.NoError(t, err)
+	isMultisig, err := IsMultisigTx(txBytes)
+	require.NoError(t, err)
+	assert.False(t, isMultisig)
+
+	txConfig := NewTxConfig()
+	signedTx, err := txConfig.TxDecoder()(txBytes)
+	require.NoError(t, err)
+	sigTx := signedTx.(xauthsigning.SigVerifiableTx)
+	sigs, err := sigTx.GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	assert.Equal(t, uint64(7), sigs[0].Sequence)
+
+	signerData := xauthsigning.SignerData{ChainID: unsignedTx.ChainID, AccountNumber: 3, Sequence: 7}
+	err = xauthsigning.VerifySignature(account.PubKey(), signerData, sigs[0].Data, txConfig.SignModeHandler(), signedTx)
+	assert.NoError(t, err)
+
+	// the account number is part of the signed data
+	signerData.AccountNumber = 4
+	err = xauthsigning.VerifySignature(account.PubKey(), signerData, sigs[0].Data, txConfig.SignModeHandler(), signedTx)
+	assert.Error(t, err)
+}
+
+func TestSignTxErrors(t *testing.T) {
+	account, err := NewAccountFromMnemonic("cold", testMnemonic)
+	require.NoError(t, err)
+	another, _, err := NewAccount("another")
+	require.NoError(t, err)
+	watchOnly, err := NewAccountFromPubKey("watch-only", account.PubKey())
+	require.NoError(t, err)
+
+	unsignedTx := newTestUnsignedTx(t, account)
+	_, err = SignTx(unsignedTx, another)
+	assert.ErrorContains(t, err, "not built for the account")
+
+	_, err = SignTx(unsignedTx, watchOnly)
+	assert.ErrorIs(t, err, ErrorWatchOnlyAccount)
+
+	unsignedTx.Sequence = 8
+	_, err = SignTx(unsignedTx, account)
+	assert.ErrorContains(t, err, "does not match")
+}